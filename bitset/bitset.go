@@ -95,6 +95,14 @@ func (b *BitSet) Set(i uint) *BitSet {
 	return b
 }
 
+// Test whether bit i is set.
+func (b *BitSet) Test(i uint) bool {
+	if i >= BITS {
+		return false
+	}
+	return b[i>>log2WordSize]&(1<<wordsIndex(i)) != 0
+}
+
 // Intersection of base set and other set
 // This is the BitSet equivalent of & (and)
 // In case of allocation failure, the function will return an empty BitSet.
@@ -138,11 +146,18 @@ func (b *BitSet) IntersectionBitCount(compare *BitSet) int {
 func (b *BitSet) Difference(compare *BitSet) *BitSet {
 	result := &BitSet{}
 	for i := range compare {
-		result[i] = b[i] ^ compare[i]
+		result[i] = b[i] &^ compare[i]
 	}
 	return result
 }
 
+// DifferenceInPlace stores the difference in result, which can be b
+func (b *BitSet) DifferenceInPlace(compare *BitSet, result *BitSet) {
+	for i, word := range b {
+		result[i] = word &^ compare[i]
+	}
+}
+
 // Count (number of set bits).
 // Also known as "popcount" or "population count".
 func (b *BitSet) Count() uint {
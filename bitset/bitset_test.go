@@ -18,3 +18,16 @@ func TestBest(t *testing.T) {
 		assert.Equal(t, uint(1+bitnum), b.Count())
 	}
 }
+
+func TestDifference(t *testing.T) {
+	b := New(LENGTH)
+	b.Set(1).Set(2).Set(LENGTH - 1)
+	compare := New(LENGTH)
+	compare.Set(2).Set(3)
+	difference := b.Difference(compare)
+	assert.Equal(t, uint(2), difference.Count())
+	assert.True(t, difference.Test(1))
+	assert.False(t, difference.Test(2))
+	assert.False(t, difference.Test(3))
+	assert.True(t, difference.Test(LENGTH-1))
+}
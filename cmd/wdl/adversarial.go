@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+type adversarialResult struct {
+	first   string
+	guesses int
+	solved  bool
+}
+
+// simulateAdversarial plays one game for each of the initial guesses against an adversarial host and prints the
+// first words that survive it best
+func simulateAdversarial(ctx context.Context, d *wordle.Dictionary, simConfig SimulateConfiguration, initialGuesesList [][]wordle.WordleWord) error {
+	tieBreak, ok := wordle.ParseTieBreak(simConfig.tieBreak)
+	if !ok {
+		return cli.Exit("tie-break must be one of: "+wordle.TieBreakNames(), 1)
+	}
+	results := []adversarialResult{}
+	for _, initialGuesses := range initialGuesesList {
+		if ctx.Err() != nil {
+			break
+		}
+		host := wordle.NewAdversarialHost(d, tieBreak, 1)
+		guesses, answers, solved := wordle.SimulateHostGame(d, host, initialGuesses, simConfig.maxGuesses)
+		fmt.Print(d.String(initialGuesses[0]), ":")
+		for i, guess := range guesses {
+			fmt.Print(" ", d.String(guess), "/", answers[i].String())
+		}
+		if !solved {
+			fmt.Print(" failed, ", host.PossibleWords().Len(), " words left")
+		}
+		fmt.Println()
+		results = append(results, adversarialResult{first: d.String(initialGuesses[0]), guesses: len(guesses), solved: solved})
+	}
+
+	fmt.Println("By guesses against the adversary")
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].solved != results[j].solved {
+			return results[i].solved
+		}
+		return results[i].guesses < results[j].guesses
+	})
+	for _, result := range results {
+		if result.solved {
+			fmt.Println(result.first, result.guesses)
+		} else {
+			fmt.Println(result.first, "failed")
+		}
+	}
+	if ctx.Err() != nil {
+		return cli.Exit("interrupted", 130)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// ArchivePuzzle is one past puzzle, a line of the archive file: number, date and solution
+type ArchivePuzzle struct {
+	Number   int
+	Date     string // see HISTORY_DATE
+	Solution string
+}
+
+// readArchive returns the puzzles in a CSV file with the columns number, date and solution.  A first row starting
+// with number is a header.
+func readArchive(alphabet *wordle.Alphabet, filename string) ([]ArchivePuzzle, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	ret := []ArchivePuzzle{}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "number") {
+			continue
+		}
+		number, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: puzzle number is not a number: %s", filename, i+1, record[0])
+		}
+		if _, err := time.Parse(HISTORY_DATE, record[1]); err != nil {
+			return nil, fmt.Errorf("%s line %d: date must be like %s: %s", filename, i+1, HISTORY_DATE, record[1])
+		}
+		ret = append(ret, ArchivePuzzle{Number: number, Date: record[1], Solution: alphabet.Normalize(record[2])})
+	}
+	return ret, nil
+}
+
+// pastSolutions are the solutions of the puzzles before the date that are in the dictionary
+func pastSolutions(d *wordle.Dictionary, archive []ArchivePuzzle, asOf string) *wordle.WordList {
+	ret := d.WordlistEmpty()
+	for _, puzzle := range archive {
+		if puzzle.Date >= asOf {
+			continue
+		}
+		if word, ok := d.Word(puzzle.Solution); ok {
+			ret.Insert(word)
+		}
+	}
+	return ret
+}
+
+// archiveFlag is the --archive flag of the commands that use past puzzles
+func archiveFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "archive",
+		Value: "archive.csv",
+		Usage: "CSV file of the past puzzles with the columns number, date (" + HISTORY_DATE + ") and solution",
+	}
+}
+
+// archive replays a past puzzle: the solver plays it with and without the solutions of the earlier puzzles and the
+// guesses, if any, are reviewed like the review command
+func archive(globalConfig GlobalConfiguration, archiveFile string, first string, args []string) error {
+	d := globalConfig.dictionary
+	if len(args) == 0 {
+		return cli.Exit("must have the puzzle number", 1)
+	}
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return cli.Exit("puzzle number is not a number: "+args[0], 1)
+	}
+	puzzles, err := readArchive(d.Alphabet(), archiveFile)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	var puzzle ArchivePuzzle
+	found := false
+	for _, archivePuzzle := range puzzles {
+		if archivePuzzle.Number == number {
+			puzzle, found = archivePuzzle, true
+		}
+	}
+	if !found {
+		return cli.Exit(fmt.Sprintf("puzzle %d is not in %s", number, archiveFile), 1)
+	}
+	solution, ok := d.Word(puzzle.Solution)
+	if !ok {
+		return cli.Exit("solution not in dictionary: "+puzzle.Solution, 1)
+	}
+	firstGuess, ok := d.Word(first)
+	if !ok {
+		return cli.Exit("first word not in dictionary: "+first, 1)
+	}
+	fmt.Printf("puzzle %d %s: %s\n", puzzle.Number, puzzle.Date, puzzle.Solution)
+	past := pastSolutions(d, puzzles, puzzle.Date)
+	remaining := d.WordlistMinus(d.WordlistAll(), past)
+	fmt.Printf("past solutions excluded: %d, possible solutions: %d\n", past.Len(), remaining.Len())
+	solve := func(name string, possibleWords *wordle.WordList) {
+		guesses, solved := wordle.SimulateOneGameFrom(d, possibleWords, solution, []wordle.WordleWord{firstGuess}, wordle.DefaultMaxGuesses)
+		fmt.Printf("%s starting with %s: %s", name, first, strings.Join(d.WordSliceToStrings(guesses), " "))
+		if !solved {
+			fmt.Print(" failed")
+		}
+		fmt.Println()
+	}
+	solve("solver", d.WordlistAll())
+	if past.Contains(solution) {
+		// the solution is not one of the remaining words, the solver excluding past solutions could not find it
+		fmt.Println("the solution is a repeat of a past solution")
+	} else {
+		solve("solver excluding past solutions", remaining)
+	}
+	if len(args) > 1 {
+		return review(globalConfig, false, append([]string{puzzle.Solution}, args[1:]...))
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	d := globalCofiguration(200, false, DictionarySource{}).dictionary
+	filename := filepath.Join(t.TempDir(), "archive.csv")
+	assert.NoError(t, os.WriteFile(filename, []byte("number,date,solution\n1,2026-10-01,acrid\n2,2026-10-02,ABOUT\n3,2026-10-03,zzzzz\n4,2026-10-04,admin\n"), 0644))
+	puzzles, err := readArchive(wordle.English, filename)
+	assert.NoError(t, err)
+	assert.Equal(t, ArchivePuzzle{Number: 2, Date: "2026-10-02", Solution: "about"}, puzzles[1])
+	assert.Equal(t, []string{"about", "acrid"}, d.WordlistStrings(pastSolutions(d, puzzles, "2026-10-04")))
+
+	playConfig := PlayConfiguration{past: puzzles, asOf: "2026-10-04"}
+	possibleWords, err := playConfig.excludePast(d, d.WordlistFromStrings([]string{"about", "acrid", "admin"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, d.WordlistStrings(possibleWords))
+	_, err = playConfig.excludePast(d, d.WordlistFromStrings([]string{"about"}))
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(filename, []byte("1,10/01/2026,acrid\n"), 0644))
+	_, err = readArchive(wordle.English, filename)
+	assert.ErrorContains(t, err, "date must be like")
+}
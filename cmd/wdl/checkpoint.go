@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/powellquiring/wordle/wordle"
+)
+
+// CheckpointGame is one simulated game stored in the checkpoint file
+type CheckpointGame struct {
+	Solution string   `json:"solution"`
+	Guesses  []string `json:"guesses"`
+	Failed   bool     `json:"failed,omitempty"` // not solved within the guess limit
+}
+
+// CheckpointRecord holds all of the games for one set of initial guesses, it is one line of the checkpoint file
+type CheckpointRecord struct {
+	First      []string         `json:"first"`
+	Solutions  string           `json:"solutions"`   // fingerprint of the simulated solutions, see SolutionsFingerprint
+	MaxGuesses int              `json:"max_guesses"` // games not solved in this many guesses are failed
+	Games      []CheckpointGame `json:"games"`
+}
+
+// Checkpoint appends a record to the checkpoint file as the simulation of each set of initial guesses finishes
+type Checkpoint struct {
+	file       *os.File
+	solutions  string // fingerprint of the solutions of this simulation
+	maxGuesses int    // guess limit of this simulation
+	completed  map[string]CheckpointRecord
+}
+
+// SolutionsFingerprint identifies the set of simulated solutions, records of a simulation of other solutions, for
+// example with a different --count, are not resumed
+func SolutionsFingerprint(d *wordle.Dictionary, solutions *wordle.WordList) string {
+	sum := sha256.Sum256([]byte(strings.Join(d.WordlistStrings(solutions), " ")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func checkpointKey(first []string, solutions string, maxGuesses int) string {
+	return fmt.Sprintf("%s/%s/%d", strings.Join(first, " "), solutions, maxGuesses)
+}
+
+// OpenCheckpoint creates the checkpoint file for a simulation of the solutions with the fingerprint and the guess
+// limit, the records of a simulation with another guess limit are not resumed.  When resuming
+// the records already in the file are read and new records are appended.  An existing file is only used when
+// resuming, it is never overwritten.
+func OpenCheckpoint(filename string, resume bool, solutions string, maxGuesses int) (*Checkpoint, error) {
+	ret := &Checkpoint{solutions: solutions, maxGuesses: maxGuesses, completed: make(map[string]CheckpointRecord)}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_EXCL
+	if resume {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		if err := ret.read(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(filename, flag, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return nil, fmt.Errorf("%s already exists, use --resume to continue it or remove it", filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret.file = file
+	return ret, nil
+}
+
+func (c *Checkpoint) read(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var record CheckpointRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// a partial last line is left behind if the process was killed while writing, it is simulated again
+			continue
+		}
+		c.completed[checkpointKey(record.First, record.Solutions, record.MaxGuesses)] = record
+	}
+	return scanner.Err()
+}
+
+// Completed returns the games stored for the initial guesses, sorted by number of guesses like simulate
+func (c *Checkpoint) Completed(d *wordle.Dictionary, first []string) (map[int][]Game, bool) {
+	record, ok := c.completed[checkpointKey(first, c.solutions, c.maxGuesses)]
+	if !ok {
+		return nil, false
+	}
+	sortedGames := make(map[int][]Game)
+	for _, checkpointGame := range record.Games {
+		solution, ok := d.Word(checkpointGame.Solution)
+		if !ok {
+			return nil, false // checkpoint was written with a different dictionary
+		}
+		guesses := []wordle.WordleWord{}
+		for _, guessString := range checkpointGame.Guesses {
+			guess, ok := d.Word(guessString)
+			if !ok {
+				return nil, false
+			}
+			guesses = append(guesses, guess)
+		}
+		numberGuesses := len(guesses)
+		if checkpointGame.Failed {
+			numberGuesses = FAILED
+		}
+		sortedGames[numberGuesses] = append(sortedGames[numberGuesses], Game{solution, guesses})
+	}
+	return sortedGames, true
+}
+
+// Write appends the games for the initial guesses and flushes them to disk
+func (c *Checkpoint) Write(d *wordle.Dictionary, first []string, sortedGames map[int][]Game) error {
+	record := CheckpointRecord{First: first, Solutions: c.solutions, MaxGuesses: c.maxGuesses, Games: []CheckpointGame{}}
+	for numberGuesses, games := range sortedGames {
+		for _, game := range games {
+			record.Games = append(record.Games, CheckpointGame{Solution: d.String(game.Solution), Guesses: d.WordSliceToStrings(game.Guesses), Failed: numberGuesses == FAILED})
+		}
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return c.file.Sync()
+}
+
+func (c *Checkpoint) Close() error {
+	return c.file.Close()
+}
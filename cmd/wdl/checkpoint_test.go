@@ -0,0 +1,43 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint(t *testing.T) {
+	d := globalCofiguration(200, false, DictionarySource{}).dictionary
+	filename := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	solutions := SolutionsFingerprint(d, d.WordlistFromStrings([]string{"about", "acrid"}))
+	checkpoint, err := OpenCheckpoint(filename, false, solutions, 6)
+	assert.NoError(t, err)
+	games := map[int][]Game{2: {{d.WordlistFromStrings([]string{"about"}).FirstWord(), d.StringsToWordSlice([]string{"acrid", "about"})}}}
+	assert.NoError(t, checkpoint.Write(d, []string{"acrid"}, games))
+	assert.NoError(t, checkpoint.Close())
+
+	_, err = OpenCheckpoint(filename, false, solutions, 6)
+	assert.ErrorContains(t, err, "already exists")
+
+	checkpoint, err = OpenCheckpoint(filename, true, solutions, 6)
+	assert.NoError(t, err)
+	completed, ok := checkpoint.Completed(d, []string{"acrid"})
+	assert.True(t, ok)
+	assert.Equal(t, games, completed)
+	assert.NoError(t, checkpoint.Close())
+
+	// a different set of solutions is simulated again
+	checkpoint, err = OpenCheckpoint(filename, true, SolutionsFingerprint(d, d.WordlistFromStrings([]string{"about"})), 6)
+	assert.NoError(t, err)
+	_, ok = checkpoint.Completed(d, []string{"acrid"})
+	assert.False(t, ok)
+	assert.NoError(t, checkpoint.Close())
+
+	// failures at a smaller guess limit are simulated again
+	checkpoint, err = OpenCheckpoint(filename, true, solutions, 8)
+	assert.NoError(t, err)
+	_, ok = checkpoint.Completed(d, []string{"acrid"})
+	assert.False(t, ok)
+	assert.NoError(t, checkpoint.Close())
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// deduce prints the solutions that can produce all of the share grids in the files, a file of - is stdin
+func deduce(globalConfig GlobalConfiguration, top int, files []string) error {
+	d := globalConfig.dictionary
+	grids := [][]wordle.Answer{}
+	for _, file := range files {
+		var text []byte
+		var err error
+		if file == "-" {
+			text, err = io.ReadAll(os.Stdin)
+		} else {
+			text, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		grid, err := wordle.ParseShareGrid(string(text))
+		if err != nil {
+			return cli.Exit(file+": "+err.Error(), 1)
+		}
+		grids = append(grids, grid)
+	}
+	deductions := d.Deduce(grids)
+	if len(deductions) == 0 {
+		return cli.Exit("no solution can produce all of the share grids", 3)
+	}
+	fmt.Println("possible solutions:", len(deductions))
+	// relative likelihood of each solution compared to the others, assuming every solution is equally likely
+	total := 0.0
+	best := deductions[0].LogLikelihood
+	for _, deduction := range deductions {
+		total += math.Exp(deduction.LogLikelihood - best)
+	}
+	for i, deduction := range deductions {
+		if i >= top {
+			break
+		}
+		fmt.Printf("%s %5.1f%%\n", d.String(deduction.Solution), 100*math.Exp(deduction.LogLikelihood-best)/total)
+	}
+	return nil
+}
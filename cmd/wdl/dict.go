@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/powellquiring/wordle/gowordle"
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// readWordFile returns the lines of a word list, one word per line
+func readWordFile(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// readFrequencyFile returns the frequency of each word in a file with a word and a number on each line separated by
+// spaces, a tab or a comma.  The words are normalized for the alphabet and blank lines are skipped.
+func readFrequencyFile(alphabet *wordle.Alphabet, filename string) (map[string]float64, error) {
+	lines, err := readWordFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]float64{}
+	for i, line := range lines {
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s line %d: must be a word and a frequency: %q", filename, i+1, line)
+		}
+		frequency, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || frequency < 0 {
+			return nil, fmt.Errorf("%s line %d: frequency must be a positive number: %q", filename, i+1, fields[1])
+		}
+		ret[alphabet.Normalize(fields[0])] += frequency
+	}
+	return ret, nil
+}
+
+// dictCheck prints the problems with the word list
+func dictCheck(alphabet *wordle.Alphabet, filename string) error {
+	lines, err := readWordFile(filename)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	problems := alphabet.CheckWords(lines)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return cli.Exit(fmt.Sprintf("%s: %d problems, see wdl dict normalize", filename, len(problems)), 1)
+	}
+	fmt.Printf("%s: %d words ok\n", filename, len(lines))
+	return nil
+}
+
+// dictNormalize writes the cleaned sorted word list to the output file or stdout
+func dictNormalize(alphabet *wordle.Alphabet, filename string, output string) error {
+	lines, err := readWordFile(filename)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	words, dropped := alphabet.NormalizeWords(lines)
+	for _, word := range dropped {
+		fmt.Fprintf(os.Stderr, "dropped %q\n", word)
+	}
+	var out io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		defer file.Close()
+		out = file
+	}
+	for _, word := range words {
+		fmt.Fprintln(out, word)
+	}
+	return nil
+}
+
+// savedOpeners returns the first words that have a file in the saved directory, see sim --replace
+func savedOpeners(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	openers := []string{}
+	for _, file := range files {
+		openers = append(openers, strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+	return openers, nil
+}
+
+// dictDiff prints the solutions added and removed between the word lists and how the answers to each opener change
+func dictDiff(alphabet *wordle.Alphabet, oldFilename, newFilename string, openers []string, savedDir string) error {
+	lists := [][]string{}
+	for _, filename := range []string{oldFilename, newFilename} {
+		lines, err := readWordFile(filename)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		if problems := alphabet.CheckWords(lines); len(problems) > 0 {
+			return cli.Exit(fmt.Sprintf("%s: %d problems, see wdl dict check", filename, len(problems)), 1)
+		}
+		lists = append(lists, lines)
+	}
+	oldWords, newWords := lists[0], lists[1]
+	added, removed := wordle.DiffWords(oldWords, newWords)
+	fmt.Printf("words: %d -> %d\n", len(oldWords), len(newWords))
+	fmt.Println("added:", strings.Join(added, " "))
+	fmt.Println("removed:", strings.Join(removed, " "))
+
+	saved, err := savedOpeners(savedDir)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	openers = append(openers, saved...)
+	if len(openers) == 0 {
+		fmt.Println("no openers, use --opener or sim --replace to save some in", savedDir)
+		return nil
+	}
+	sort.Strings(openers)
+	for _, opener := range openers {
+		if problems := alphabet.CheckWords([]string{opener}); len(problems) > 0 {
+			return cli.Exit("opener "+problems[0].String(), 1)
+		}
+		oldAnswers := gowordle.UniqueAnswerResults(oldWords, opener)
+		newAnswers := gowordle.UniqueAnswerResults(newWords, opener)
+		fmt.Printf("%s: answers %d -> %d, largest %d -> %d\n", opener, len(oldAnswers), len(newAnswers), largestAnswer(oldAnswers), largestAnswer(newAnswers))
+		for _, change := range []struct {
+			sign  string
+			words []string
+		}{{"+", added}, {"-", removed}} {
+			for _, word := range change.words {
+				colors := gowordle.WordleAnswer(gowordle.WordleWord([]rune(word)), gowordle.WordleWord([]rune(opener)))
+				answer := string(colors[:])
+				fmt.Printf("  %s %s %s candidates %d -> %d\n", change.sign, word, answer, len(oldAnswers[answer]), len(newAnswers[answer]))
+			}
+		}
+	}
+	return nil
+}
+
+// largestAnswer is the most solutions that share an answer
+func largestAnswer(answerSolutions map[string][]string) int {
+	ret := 0
+	for _, solutions := range answerSolutions {
+		ret = max(ret, len(solutions))
+	}
+	return ret
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+// explain the top guesses for the board given by the guess/answer pairs
+func explain(globalConfig GlobalConfiguration, top int, answers []string) error {
+	d := globalConfig.dictionary
+	guessAnswers, err := parseGuessAnswers(d, answers)
+	if err != nil {
+		return err
+	}
+	if contradiction, ok := d.FindContradiction(guessAnswers); ok {
+		printContradiction(contradiction)
+		return cli.Exit("no words match the guess answer pairs", 3)
+	}
+	possibleWords := d.PossibleWords(guessAnswers)
+	fmt.Println("possible words:", possibleWords.Len())
+	fmt.Printf("%-6s %8s %5s %7s %7s %7s %9s\n", "guess", "expected", "worst", "buckets", "entropy", "largest", "candidate")
+	for _, report := range d.ExplainGuesses(possibleWords, top) {
+		fmt.Printf("%-6s %8.3f %5d %7d %7.3f %7d %9t\n", d.String(report.Guess), report.Expected, report.WorstCase, report.Buckets, report.Entropy, report.LargestBucket, report.Candidate)
+	}
+	return nil
+}
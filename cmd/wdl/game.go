@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// ANSI background colors for the tiles of an answer
+var tileColors = map[rune]string{
+	'r': "\033[37;100m",
+	'y': "\033[30;43m",
+	'g': "\033[30;42m",
+}
+
+const tileReset = "\033[0m"
+
+// coloredGuess returns the guess letters on the colors of the answer
+func coloredGuess(guess string, answer wordle.Answer) string {
+	ret := ""
+	colors := []rune(answer.String())
+	for i, letter := range []rune(guess) {
+		ret += tileColors[colors[i]] + " " + strings.ToUpper(string(letter)) + " " + tileReset
+	}
+	return ret
+}
+
+type GameConfiguration struct {
+	seed        int64           // picks the hidden word, or breaks ties for the random tie break, 0 is the time
+	adversarial bool            // the host never commits to a word, see wordle.AdversarialHost
+	tieBreak    wordle.TieBreak // how the adversarial host chooses between answers that keep as many words
+	tileSet     wordle.TileSet  // emoji of the share text
+	number      int             // puzzle number of the share text, 0 for none
+	hardMode    bool            // every guess must use the hints of the answers before it, see wordle.IsHardMode
+}
+
+// newHost returns the host and the solution, if the host has one.  Each call returns a host that answers the same
+// way for a non zero seed.
+func (c GameConfiguration) newHost(d *wordle.Dictionary) (wordle.Host, string) {
+	if c.adversarial {
+		return wordle.NewAdversarialHost(d, c.tieBreak, c.seed), ""
+	}
+	solution := randomSolution(d, c.seed)
+	return wordle.NewSolutionHost(d, solution), d.String(solution)
+}
+
+// solutionDescription is the solution, or what is left of the words when the host is adversarial
+func solutionDescription(d *wordle.Dictionary, host wordle.Host, solution string) string {
+	if adversarialHost, ok := host.(*wordle.AdversarialHost); ok {
+		possibleWords := d.WordlistStrings(adversarialHost.PossibleWords())
+		return fmt.Sprintf("one of %d words: %s", len(possibleWords), strings.Join(possibleWords[0:min(len(possibleWords), 10)], " "))
+	}
+	return solution
+}
+
+// game lets a person guess a hidden solution, then compares the result with the solver starting from the same first guess
+func game(globalConfig GlobalConfiguration, gameConfig GameConfiguration, in io.Reader, out io.Writer) error {
+	d := globalConfig.dictionary
+	if gameConfig.seed == 0 {
+		// the solver plays against the same host
+		gameConfig.seed = time.Now().UnixNano()
+	}
+	host, solution := gameConfig.newHost(d)
+	scanner := bufio.NewScanner(in)
+	guesses := []wordle.WordleWord{}
+	answers := []wordle.Answer{}
+	solved := false
+	for len(guesses) < wordle.DefaultMaxGuesses && !solved {
+		fmt.Fprintf(out, "guess %d/%d: ", len(guesses)+1, wordle.DefaultMaxGuesses)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return cli.Exit("game abandoned, the solution was "+solutionDescription(d, host, solution), 1)
+		}
+		guessString := d.Alphabet().Normalize(scanner.Text())
+		guess, ok := d.Word(guessString)
+		if !ok {
+			fmt.Fprintln(out, "not in word list:", guessString)
+			continue
+		}
+		if gameConfig.hardMode && !wordle.IsHardMode(append(d.WordSliceToStrings(guesses), guessString), answers) {
+			fmt.Fprintln(out, "hard mode, use the hints of the earlier answers:", guessString)
+			continue
+		}
+		guesses = append(guesses, guess)
+		answer := host.Answer(guess)
+		answers = append(answers, answer)
+		fmt.Fprintln(out, coloredGuess(guessString, answer))
+		solved = answer == wordle.AllGreenAnswer
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if solved {
+		fmt.Fprintf(out, "solved in %d/%d\n", len(guesses), wordle.DefaultMaxGuesses)
+	} else {
+		fmt.Fprintf(out, "failed, the solution was %s\n", solutionDescription(d, host, solution))
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, wordle.ShareText(gameConfig.number, answers, solved, wordle.DefaultMaxGuesses, gameConfig.hardMode, gameConfig.tileSet))
+	fmt.Fprintln(out)
+	solverHost, _ := gameConfig.newHost(d)
+	solverGuesses, _, solverSolved := wordle.SimulateHostGame(d, solverHost, guesses[0:1], wordle.DefaultMaxGuesses)
+	fmt.Fprintln(out, "solver starting with", d.String(guesses[0])+":", strings.Join(d.WordSliceToStrings(solverGuesses), " "))
+	switch {
+	case !solverSolved && !solved:
+		fmt.Fprintln(out, "neither you nor the solver found it")
+	case !solverSolved:
+		fmt.Fprintln(out, "you beat the solver, it failed")
+	case !solved:
+		fmt.Fprintf(out, "the solver wins, it needed %d\n", len(solverGuesses))
+	case len(guesses) < len(solverGuesses):
+		fmt.Fprintf(out, "you beat the solver by %d\n", len(solverGuesses)-len(guesses))
+	case len(guesses) > len(solverGuesses):
+		fmt.Fprintf(out, "the solver wins by %d\n", len(guesses)-len(solverGuesses))
+	default:
+		fmt.Fprintln(out, "tied with the solver")
+	}
+	return nil
+}
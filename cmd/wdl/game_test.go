@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame(t *testing.T) {
+	globalConfig := globalCofiguration(200, false, DictionarySource{})
+	d := globalConfig.dictionary
+	solution := d.String(randomSolution(d, 1))
+	out := &strings.Builder{}
+	err := game(globalConfig, GameConfiguration{seed: 1}, strings.NewReader("zzzzz\nabout\n"+solution+"\n"), out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "not in word list: zzzzz")
+	assert.Contains(t, out.String(), "solver starting with about:")
+	if solution == "about" {
+		assert.Contains(t, out.String(), "solved in 1/6")
+	} else {
+		assert.Contains(t, out.String(), "solved in 2/6")
+	}
+
+	out.Reset()
+	err = game(globalConfig, GameConfiguration{seed: 1, number: 1234, hardMode: true}, strings.NewReader(solution+"\n"), out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Wordle 1234 1/6*")
+
+	// the solution starts with the a of about, the only hint, so hard mode rejects beach
+	out.Reset()
+	err = game(globalConfig, GameConfiguration{seed: 1, hardMode: true}, strings.NewReader("about\nbeach\n"+solution+"\n"), out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "hard mode, use the hints of the earlier answers: beach")
+	assert.Contains(t, out.String(), "solved in 2/6")
+
+	err = game(globalConfig, GameConfiguration{seed: 1}, strings.NewReader("about\n"), out)
+	assert.ErrorContains(t, err, "the solution was "+solution)
+}
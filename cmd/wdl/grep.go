@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// GrepConfiguration are the grep command flags
+type GrepConfiguration struct {
+	has       string   // letters in the word, a letter repeated n times is in the word at least n times
+	not       string   // letters not in the word
+	minCounts []string // letter=count, the word has at least count of the letter
+	byWeight  bool     // list the most frequent words first
+}
+
+// grepConstraint makes the constraint of the pattern and the flags, a ? or . in the pattern is any letter
+func grepConstraint(alphabet *wordle.Alphabet, pattern string, grepConfig GrepConfiguration) (wordle.Constraint, error) {
+	ret := wordle.Constraint{MinCount: map[rune]int{}, MaxCount: map[rune]int{}}
+	letters := func(flag string, value string) ([]rune, error) {
+		ret := []rune(alphabet.Normalize(value))
+		for _, letter := range ret {
+			if !alphabet.Contains(letter) {
+				return nil, cli.Exit(fmt.Sprintf("%s: %c is not a letter of the %s alphabet", flag, letter, alphabet.Name), 1)
+			}
+		}
+		return ret, nil
+	}
+	atLeast := func(letter rune, count int) {
+		ret.MinCount[letter] = max(ret.MinCount[letter], count)
+	}
+
+	patternLetters := []rune(alphabet.Normalize(pattern))
+	if len(patternLetters) != 5 {
+		return ret, cli.Exit("pattern must have 5 letters or ?, like s?a?e", 1)
+	}
+	for position, letter := range patternLetters {
+		if letter == '?' || letter == '.' {
+			continue
+		}
+		if !alphabet.Contains(letter) {
+			return ret, cli.Exit(fmt.Sprintf("pattern: %c is not a letter of the %s alphabet or ?", letter, alphabet.Name), 1)
+		}
+		ret.Greens[position] = letter
+	}
+
+	has, err := letters("has", grepConfig.has)
+	if err != nil {
+		return ret, err
+	}
+	for _, letter := range has {
+		atLeast(letter, strings.Count(string(has), string(letter)))
+	}
+	not, err := letters("not", grepConfig.not)
+	if err != nil {
+		return ret, err
+	}
+	for _, letter := range not {
+		if ret.MinCount[letter] > 0 {
+			return ret, cli.Exit(fmt.Sprintf("%c is in both --has and --not", letter), 1)
+		}
+		if strings.ContainsRune(string(ret.Greens[:]), letter) {
+			return ret, cli.Exit(fmt.Sprintf("%c is in both the pattern and --not", letter), 1)
+		}
+		ret.MaxCount[letter] = 0
+	}
+	for _, minCount := range grepConfig.minCounts {
+		letterString, countString, ok := strings.Cut(minCount, "=")
+		letter, err := letters("min-count", letterString)
+		if err != nil {
+			return ret, err
+		}
+		count, countErr := strconv.Atoi(countString)
+		if !ok || len(letter) != 1 || countErr != nil || count < 1 || count > 5 {
+			return ret, cli.Exit("min-count must be like x=2, a letter and a count from 1 to 5: "+minCount, 1)
+		}
+		if _, ok := ret.MaxCount[letter[0]]; ok {
+			return ret, cli.Exit(fmt.Sprintf("%c is in both --min-count and --not", letter[0]), 1)
+		}
+		atLeast(letter[0], count)
+	}
+	return ret, nil
+}
+
+// grepWords returns the words of the dictionary that match the pattern and flags, alphabetical or the most frequent
+// first
+func grepWords(d *wordle.Dictionary, pattern string, grepConfig GrepConfiguration) ([]wordle.WordleWord, error) {
+	if grepConfig.byWeight && !d.Weighted() {
+		return nil, cli.Exit("by-weight needs the word frequencies, see --frequencies", 1)
+	}
+	constraint, err := grepConstraint(d.Alphabet(), pattern, grepConfig)
+	if err != nil {
+		return nil, err
+	}
+	ret := constraint.Matching(d).Words()
+	if grepConfig.byWeight {
+		sort.SliceStable(ret, func(i, j int) bool { return d.Weight(ret[i]) > d.Weight(ret[j]) })
+	}
+	return ret, nil
+}
+
+// grep prints the words that match the pattern and flags, one per line
+func grep(globalConfig GlobalConfiguration, pattern string, grepConfig GrepConfiguration) error {
+	d := globalConfig.dictionary
+	words, err := grepWords(d, pattern, grepConfig)
+	if err != nil {
+		return err
+	}
+	for _, word := range words {
+		if grepConfig.byWeight {
+			fmt.Println(d.String(word), d.Weight(word))
+		} else {
+			fmt.Println(d.String(word))
+		}
+	}
+	return nil
+}
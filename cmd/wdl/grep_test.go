@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrep(t *testing.T) {
+	d := globalCofiguration(0, false, DictionarySource{}).dictionary
+	words, err := grepWords(d, "s?a?e", GrepConfiguration{has: "r", not: "t"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"scare", "share", "snare", "spare"}, d.WordSliceToStrings(words))
+	words, err = grepWords(d, "?????", GrepConfiguration{minCounts: []string{"s=3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sassy", "sissy"}, d.WordSliceToStrings(words))
+	words, err = grepWords(d, "?????", GrepConfiguration{has: "ss", not: "aeiou", minCounts: []string{"y=1"}})
+	assert.NoError(t, err)
+	assert.Empty(t, words)
+	words, err = grepWords(d, "?????", GrepConfiguration{has: "ss", not: "aeio", minCounts: []string{"y=1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fussy", "hussy"}, d.WordSliceToStrings(words))
+
+	_, err = grepWords(d, "s?a?", GrepConfiguration{})
+	assert.Error(t, err)
+	_, err = grepWords(d, "?????", GrepConfiguration{minCounts: []string{"x"}})
+	assert.Error(t, err)
+	_, err = grepWords(d, "?????", GrepConfiguration{has: "x", not: "x"})
+	assert.ErrorContains(t, err, "x is in both --has and --not")
+	_, err = grepWords(d, "?????", GrepConfiguration{not: "x", minCounts: []string{"x=1"}})
+	assert.ErrorContains(t, err, "x is in both --min-count and --not")
+	_, err = grepWords(d, "s?a?e", GrepConfiguration{not: "s"})
+	assert.ErrorContains(t, err, "s is in both the pattern and --not")
+	_, err = grepWords(d, "?????", GrepConfiguration{byWeight: true})
+	assert.ErrorContains(t, err, "frequencies")
+
+	d = wordle.NewWeightedDictionary([]string{"scare", "share", "snare"}, wordle.English, []int{1, 5, 3})
+	words, err = grepWords(d, "s?are", GrepConfiguration{byWeight: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"share", "snare", "scare"}, d.WordSliceToStrings(words))
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// HISTORY_DATE is the layout of the dates in the history file
+const HISTORY_DATE = "2006-01-02"
+
+// HistoryGame is one game played by a person, it is one line of the history file.  The skill loss and luck are
+// summed over the guesses replayed with the solver when the game is added, see review.
+type HistoryGame struct {
+	Date      string   `json:"date"`
+	Solution  string   `json:"solution"`
+	Guesses   []string `json:"guesses"`
+	HardMode  bool     `json:"hard_mode,omitempty"`
+	SkillLoss float64  `json:"skill_loss"`
+	Luck      float64  `json:"luck"`
+}
+
+// Solved is true if the last guess is the solution
+func (g HistoryGame) Solved() bool {
+	return len(g.Guesses) > 0 && g.Guesses[len(g.Guesses)-1] == g.Solution
+}
+
+// newHistoryGame checks the date and the words of a game and replays it with the solver for the skill loss and luck
+func newHistoryGame(d *wordle.Dictionary, date, solution string, guesses []string, hardMode bool) (HistoryGame, error) {
+	ret := HistoryGame{Date: date, Solution: d.Alphabet().Normalize(solution)}
+	if _, err := time.Parse(HISTORY_DATE, date); err != nil {
+		return ret, fmt.Errorf("date must be like %s: %s", HISTORY_DATE, date)
+	}
+	solutionWord, ok := d.Word(ret.Solution)
+	if !ok {
+		return ret, fmt.Errorf("solution not in dictionary: %s", solution)
+	}
+	if len(guesses) == 0 {
+		return ret, fmt.Errorf("game on %s has no guesses", date)
+	}
+	guessWords := []wordle.WordleWord{}
+	for i, guessString := range guesses {
+		guess, ok := d.Word(guessString)
+		if !ok {
+			return ret, fmt.Errorf("guess not in dictionary: %s", guessString)
+		}
+		if guess == solutionWord && i < len(guesses)-1 {
+			return ret, fmt.Errorf("game on %s has guesses after the solution", date)
+		}
+		ret.Guesses = append(ret.Guesses, d.String(guess))
+		guessWords = append(guessWords, guess)
+	}
+	ret.HardMode = hardMode
+	for _, step := range d.ReviewGame(solutionWord, guessWords, false) {
+		ret.SkillLoss += step.SkillLoss
+		ret.Luck += step.Luck
+	}
+	return ret, nil
+}
+
+// readHistory returns the games in the history file ordered by date, a missing file is an empty history
+func readHistory(filename string) ([]HistoryGame, error) {
+	ret := []HistoryGame{}
+	file, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var game HistoryGame
+		if err := json.Unmarshal(scanner.Bytes(), &game); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", filename, lineNumber, err)
+		}
+		ret = append(ret, game)
+	}
+	slices.SortStableFunc(ret, func(a, b HistoryGame) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	return ret, scanner.Err()
+}
+
+// appendHistory adds the games to the history file, there can only be one game for each date
+func appendHistory(filename string, games []HistoryGame) error {
+	history, err := readHistory(filename)
+	if err != nil {
+		return err
+	}
+	dates := map[string]bool{}
+	for _, game := range history {
+		dates[game.Date] = true
+	}
+	for _, game := range games {
+		if dates[game.Date] {
+			return fmt.Errorf("history already has a game on %s", game.Date)
+		}
+		dates[game.Date] = true
+	}
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, game := range games {
+		line, err := json.Marshal(game)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return file.Sync()
+}
+
+// historyAdd stores one game, the args are the date, the solution and the guesses
+func historyAdd(globalConfig GlobalConfiguration, filename string, hardMode bool, args []string) error {
+	if len(args) < 3 {
+		return cli.Exit("must have the date, the solution and at least one guess", 1)
+	}
+	game, err := newHistoryGame(globalConfig.dictionary, args[0], args[1], args[2:], hardMode)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	if err := appendHistory(filename, []HistoryGame{game}); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	return nil
+}
+
+// historyImport stores the games of a spreadsheet exported as CSV with the columns date, solution, guesses separated
+// by spaces and an optional hard mode column.  A first row starting with date is a header.
+func historyImport(globalConfig GlobalConfiguration, filename string, csvFile string) error {
+	file, err := os.Open(csvFile)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return cli.Exit(csvFile+": "+err.Error(), 1)
+	}
+	games := []HistoryGame{}
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		if len(record) < 3 {
+			return cli.Exit(fmt.Sprintf("%s line %d: must have the date, solution and guesses columns", csvFile, i+1), 1)
+		}
+		hardMode := false
+		if len(record) > 3 && record[3] != "" {
+			if hardMode, err = strconv.ParseBool(record[3]); err != nil {
+				return cli.Exit(fmt.Sprintf("%s line %d: hard mode must be true or false: %s", csvFile, i+1, record[3]), 1)
+			}
+		}
+		game, err := newHistoryGame(globalConfig.dictionary, record[0], record[1], strings.Fields(record[2]), hardMode)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("%s line %d: %s", csvFile, i+1, err), 1)
+		}
+		games = append(games, game)
+	}
+	if err := appendHistory(filename, games); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	fmt.Println("imported", len(games), "games")
+	return nil
+}
+
+// HistoryStreaks are runs of solved games on consecutive days
+type HistoryStreaks struct {
+	Current int // streak ending with the last game, 0 if the last game was before yesterday
+	Longest int
+}
+
+func historyStreaks(games []HistoryGame, today time.Time) HistoryStreaks {
+	ret := HistoryStreaks{}
+	var previous time.Time
+	for _, game := range games {
+		date, _ := time.Parse(HISTORY_DATE, game.Date)
+		if !game.Solved() {
+			ret.Current = 0
+		} else if ret.Current > 0 && date.Equal(previous.AddDate(0, 0, 1)) {
+			ret.Current++
+		} else {
+			ret.Current = 1
+		}
+		ret.Longest = max(ret.Longest, ret.Current)
+		previous = date
+	}
+	yesterday := time.Date(today.Year(), today.Month(), today.Day()-1, 0, 0, 0, 0, time.UTC)
+	if previous.Before(yesterday) {
+		ret.Current = 0
+	}
+	return ret
+}
+
+// historyStats prints the guess distribution, the streaks and the skill loss and luck of each game stored when it was
+// added
+func historyStats(filename string, maxGuesses int) error {
+	games, err := readHistory(filename)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	if len(games) == 0 {
+		return cli.Exit("no games in "+filename, 1)
+	}
+	distribution := make([]int, maxGuesses+1) // index 0 is the games that were not solved
+	totalSkillLoss, totalLuck := 0.0, 0.0
+	for _, game := range games {
+		if game.Solved() && len(game.Guesses) <= maxGuesses {
+			distribution[len(game.Guesses)]++
+		} else {
+			distribution[FAILED]++
+		}
+		totalSkillLoss += game.SkillLoss
+		totalLuck += game.Luck
+		hard := ""
+		if game.HardMode {
+			hard = " hard"
+		}
+		fmt.Printf("%s %s %s%s skill loss:%.3f luck:%+.3f\n", game.Date, game.Solution, strings.Join(game.Guesses, " "), hard, game.SkillLoss, game.Luck)
+	}
+	played := len(games)
+	fmt.Printf("played:%d solved:%.1f%%\n", played, 100*float64(played-distribution[FAILED])/float64(played))
+	for numberGuesses := 1; numberGuesses <= maxGuesses; numberGuesses++ {
+		fmt.Printf("%d: %d\n", numberGuesses, distribution[numberGuesses])
+	}
+	fmt.Printf("X: %d\n", distribution[FAILED])
+	streaks := historyStreaks(games, time.Now())
+	fmt.Printf("current streak:%d longest streak:%d\n", streaks.Current, streaks.Longest)
+	fmt.Printf("average skill loss:%.3f luck:%+.3f\n", totalSkillLoss/float64(played), totalLuck/float64(played))
+	return nil
+}
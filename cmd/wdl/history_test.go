@@ -0,0 +1,56 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	d := globalCofiguration(200, false, DictionarySource{}).dictionary
+	_, err := newHistoryGame(d, "10/01/2026", "avert", []string{"about", "avert"}, false)
+	assert.ErrorContains(t, err, "date must be like")
+	_, err = newHistoryGame(d, "2026-10-01", "avert", []string{"avert", "about"}, false)
+	assert.ErrorContains(t, err, "guesses after the solution")
+
+	games := []HistoryGame{}
+	for _, game := range []struct {
+		date     string
+		solution string
+		guesses  []string
+	}{
+		{"2026-10-03", "acute", []string{"about", "acute"}},
+		{"2026-10-01", "avert", []string{"about", "alert", "avert"}},
+		{"2026-10-02", "actor", []string{"about", "acorn"}},
+		{"2026-10-04", "abbey", []string{"about", "abide", "abbey"}},
+	} {
+		historyGame, err := newHistoryGame(d, game.date, game.solution, game.guesses, false)
+		assert.NoError(t, err)
+		games = append(games, historyGame)
+	}
+	assert.False(t, games[0].HardMode)
+	assert.False(t, games[2].Solved())
+	hardGame, err := newHistoryGame(d, "2026-10-05", "acute", []string{"about", "acute"}, true)
+	assert.NoError(t, err)
+	assert.True(t, hardGame.HardMode)
+	assert.Equal(t, games[0].SkillLoss, hardGame.SkillLoss)
+	assert.Equal(t, games[0].Luck, hardGame.Luck)
+
+	filename := filepath.Join(t.TempDir(), "history.jsonl")
+	assert.NoError(t, appendHistory(filename, games))
+	assert.ErrorContains(t, appendHistory(filename, games[:1]), "already has a game on 2026-10-03")
+	history, err := readHistory(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-01", history[0].Date)
+	assert.Equal(t, games[0], history[2])
+	day := func(date string) time.Time {
+		ret, _ := time.Parse(HISTORY_DATE, date)
+		return ret
+	}
+	assert.Equal(t, HistoryStreaks{Current: 2, Longest: 2}, historyStreaks(history, day("2026-10-04")))
+	assert.Equal(t, HistoryStreaks{Current: 2, Longest: 2}, historyStreaks(history, day("2026-10-05")))
+	assert.Equal(t, HistoryStreaks{Current: 0, Longest: 2}, historyStreaks(history, day("2026-10-06")))
+	assert.Equal(t, HistoryStreaks{Current: 0, Longest: 1}, historyStreaks(history[:2], day("2026-10-02")))
+}
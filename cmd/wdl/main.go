@@ -7,41 +7,132 @@ import (
 	"log"
 	"maps"
 	"os"
+	"os/signal"
 	"runtime/pprof"
 	"slices"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/powellquiring/wordle/bitset"
 	"github.com/powellquiring/wordle/wordle"
 	"github.com/urfave/cli/v3" // imports as package "cli"
 )
 
-// playWordle with guess/answer pairs provided
-func playWordle(globalConfig GlobalConfiguration, answers []string) {
-	d := globalConfig.dictionary
+// checkGuessAnswer returns an error if the guess is not in the dictionary or the answer is not made of r, y, g and ?
+func checkGuessAnswer(d *wordle.Dictionary, guessAnswer wordle.GuessAnswer) error {
+	if _, ok := d.Word(guessAnswer.Guess); !ok {
+		return fmt.Errorf("guess not in dictionary: %s", guessAnswer.Guess)
+	}
+	if _, ok := wordle.StringToPartialAnswer(guessAnswer.Answer); !ok || len(guessAnswer.Answer) != 5 {
+		return fmt.Errorf("answer not in right format r,y,g or ? for unknown like rrg?y: %s", guessAnswer.Answer)
+	}
+	return nil
+}
+
+// parseGuessAnswers turns the command line pairs of guess answer into guess/answer pairs
+func parseGuessAnswers(d *wordle.Dictionary, answers []string) ([]wordle.GuessAnswer, error) {
+	if len(answers)%2 != 0 {
+		return nil, cli.Exit("must have pairs of guess answer", 1)
+	}
 	guessAnswers := []wordle.GuessAnswer{}
 	for i := 0; i < len(answers); i += 2 {
-		guessString := answers[i]
-		answerString := answers[i+1]
-		if _, ok := d.Word(guessString); !ok {
-			panic("guess not in dictionary: " + guessString)
+		guessAnswer := wordle.GuessAnswer{Guess: d.Alphabet().Normalize(answers[i]), Answer: answers[i+1]}
+		if err := checkGuessAnswer(d, guessAnswer); err != nil {
+			return nil, cli.Exit(err.Error(), 1)
+		}
+		guessAnswers = append(guessAnswers, guessAnswer)
+	}
+	return guessAnswers, nil
+}
+
+// PlayConfiguration are the play command flags
+type PlayConfiguration struct {
+	lies int             // number of tiles in each answer with the wrong colour
+	past []ArchivePuzzle // puzzles whose solutions are not possible words, nil to keep all of the words
+	asOf string          // only the puzzles before this date are past puzzles
+}
+
+// excludePast removes the solutions of the past puzzles from the possible words
+func (c PlayConfiguration) excludePast(d *wordle.Dictionary, possibleWords *wordle.WordList) (*wordle.WordList, error) {
+	if c.past == nil {
+		return possibleWords, nil
+	}
+	ret := d.WordlistMinus(possibleWords, pastSolutions(d, c.past, c.asOf))
+	if ret.Len() == 0 {
+		return nil, cli.Exit(fmt.Sprintf("all of the %d possible words are solutions of puzzles before %s", possibleWords.Len(), c.asOf), 3)
+	}
+	return ret, nil
+}
+
+// playWordle with guess/answer pairs provided
+func playWordle(globalConfig GlobalConfiguration, playConfig PlayConfiguration, answers []string) error {
+	d := globalConfig.dictionary
+	guessAnswers, err := parseGuessAnswers(d, answers)
+	if err != nil {
+		return err
+	}
+	if lies := playConfig.lies; lies > 0 {
+		for _, guessAnswer := range guessAnswers {
+			if strings.Contains(guessAnswer.Answer, "?") {
+				return cli.Exit("? can not be used with --lies: "+guessAnswer.Answer, 1)
+			}
+		}
+		possibleWords := d.NoisyPossibleWords(guessAnswers, lies)
+		if possibleWords.Len() == 0 {
+			return cli.Exit(fmt.Sprintf("no words match the guess answer pairs with %d lies in each answer", lies), 3)
 		}
-		if _, ok := wordle.StringToAnswer(answerString); !ok {
-			panic("answer not in right format r,y,g lik rrggy" + answerString)
+		if possibleWords, err = playConfig.excludePast(d, possibleWords); err != nil {
+			return err
 		}
-		guessAnswers = append(guessAnswers, wordle.GuessAnswer{Guess: guessString, Answer: answerString})
+		fmt.Print(d.String(d.NoisyNextGuess(possibleWords, lies)), ":")
+		for _, word := range d.WordlistStrings(possibleWords) {
+			fmt.Print(" ", word)
+		}
+		fmt.Println()
+		return nil
 	}
-	nextGuess, possibleWords := d.PlayWorldReturnPossible(guessAnswers)
+	if contradiction, ok := d.FindContradiction(guessAnswers); ok {
+		printContradiction(contradiction)
+		return cli.Exit("no words match the guess answer pairs", 3)
+	}
+	possibleWords, err := playConfig.excludePast(d, d.PossibleWords(guessAnswers))
+	if err != nil {
+		return err
+	}
+	nextGuess := d.NextGuess(possibleWords)
 	fmt.Print(d.String(nextGuess), ":")
-	for _, word := range d.WordlistStrings(possibleWords) {
-		fmt.Print(" ", string(word[:]))
+	for _, word := range possibleWords.Range {
+		if d.Weighted() {
+			fmt.Printf(" %s:%.1f%%", d.String(word), 100*d.Probability(possibleWords, word))
+		} else {
+			fmt.Print(" ", d.String(word))
+		}
 	}
 	fmt.Println()
+	return nil
+}
+
+func printContradiction(contradiction wordle.Contradiction) {
+	guessAnswer := contradiction.GuessAnswer
+	fmt.Printf("pair %d (%s %s) leaves no words from the %d remaining\n", contradiction.Pair+1, guessAnswer.Guess, guessAnswer.Answer, contradiction.Remaining)
+	fmt.Printf("tile %d violates: %s\n", contradiction.Tile+1, contradiction.Constraint)
+	if len(contradiction.Corrections) == 0 {
+		fmt.Println("no single tile colour change makes the pairs consistent")
+		return
+	}
+	fmt.Println("single tile corrections:")
+	for _, correction := range contradiction.Corrections {
+		fmt.Printf("  pair %d tile %d: %s %s (%d words)\n", correction.Pair+1, correction.Tile+1, correction.GuessAnswer.Guess, correction.GuessAnswer.Answer, correction.PossibleWords)
+	}
 }
 
 type GuessResults struct {
 	Guess      string
-	Average    float64
-	GuessCount []int // number of games for each guess count
+	Average    float64 // average number of guesses for the games that were solved, 0 if none were solved
+	WinRate    float64 // fraction of the games solved within the guess limit
+	Failed     int     // number of games not solved within the guess limit
+	GuessCount []int   // number of games for each guess count
 }
 
 type Game struct {
@@ -104,53 +195,97 @@ func replaceFirstFiles(d *wordle.Dictionary, summary []map[int][]Game) {
 		}
 	}
 }
-func outputFinalSummary(d *wordle.Dictionary, summary []map[int][]Game) {
-	const MAX_GUESS_COUNT = 7
+
+// FAILED is the sortedGames key for the games that were not solved within the guess limit
+const FAILED = 0
+
+func summarizeGames(d *wordle.Dictionary, summary []map[int][]Game, maxGuesses int) []GuessResults {
 	games := make([]GuessResults, 0)
 	for _, sortedGames := range summary {
 		totalGuesses := 0
 		totalGames := 0
-		guessCount := make([]int, MAX_GUESS_COUNT)
+		guessCount := make([]int, maxGuesses+1)
 		guess := ""
-		for i := range MAX_GUESS_COUNT - 1 { // 1, 7
-			numberGuessesAtCount := i + 1 // 1, 7
-			gameGuesses, ok := sortedGames[numberGuessesAtCount]
-			if ok {
+		for numberGuessesAtCount, gameGuesses := range sortedGames {
+			if len(gameGuesses) > 0 && len(gameGuesses[0].Guesses) > 0 {
 				guess = d.String(gameGuesses[0].Guesses[0])
-			} else {
-				gameGuesses = make([]Game, 0)
+			}
+			if numberGuessesAtCount == FAILED {
+				continue
 			}
 			totalGuesses += numberGuessesAtCount * len(gameGuesses)
 			totalGames += len(gameGuesses)
 			guessCount[numberGuessesAtCount] = len(gameGuesses)
 		}
-		games = append(games, GuessResults{guess, float64(totalGuesses) / float64(totalGames), guessCount})
+		failed := len(sortedGames[FAILED])
+		average := 0.0
+		if totalGames > 0 {
+			average = float64(totalGuesses) / float64(totalGames)
+		}
+		games = append(games, GuessResults{
+			Guess:      guess,
+			Average:    average,
+			WinRate:    float64(totalGames) / float64(totalGames+failed),
+			Failed:     failed,
+			GuessCount: guessCount,
+		})
+	}
+	return games
+}
+
+// byAverage orders by average number of guesses, the results without a solved game last
+func byAverage(a, b GuessResults) bool {
+	if (a.WinRate == 0) != (b.WinRate == 0) {
+		return b.WinRate == 0
+	}
+	return a.Average < b.Average
+}
+
+func outputFinalSummary(d *wordle.Dictionary, summary []map[int][]Game, maxGuesses int) []GuessResults {
+	games := summarizeGames(d, summary, maxGuesses)
+	last := maxGuesses
+	countAt := func(gameResult GuessResults, numberGuesses int) int {
+		if numberGuesses < 1 || numberGuesses >= len(gameResult.GuessCount) {
+			return 0
+		}
+		return gameResult.GuessCount[numberGuesses]
 	}
 
 	fmt.Println("By average")
 	sort.Slice(games, func(i, j int) bool {
-		return games[i].Average < games[j].Average
+		return byAverage(games[i], games[j])
+	})
+	printGames(games)
+
+	fmt.Println("By failed")
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].Failed < games[j].Failed
 	})
 	printGames(games)
 
-	fmt.Println("By guess 6")
+	fmt.Printf("By guess %d\n", last)
 	sort.Slice(games, func(i, j int) bool {
-		return games[i].GuessCount[6] < games[j].GuessCount[6]
+		return countAt(games[i], last) < countAt(games[j], last)
 	})
 	printGames(games)
 
-	fmt.Println("By guess 5+6")
+	fmt.Printf("By guess %d+%d\n", last-1, last)
 	sort.Slice(games, func(i, j int) bool {
-		return games[i].GuessCount[6]+games[i].GuessCount[5] < games[j].GuessCount[6]+games[j].GuessCount[5]
+		return countAt(games[i], last)+countAt(games[i], last-1) < countAt(games[j], last)+countAt(games[j], last-1)
 	})
 	printGames(games)
 
 	fmt.Println("By guess 2+3")
 	sort.Slice(games, func(i, j int) bool {
-		return games[i].GuessCount[2]+games[i].GuessCount[3] > games[j].GuessCount[2]+games[j].GuessCount[3]
+		return countAt(games[i], 2)+countAt(games[i], 3) > countAt(games[j], 2)+countAt(games[j], 3)
 	})
 	printGames(games)
 	fmt.Println("done")
+
+	sort.SliceStable(games, func(i, j int) bool {
+		return byAverage(games[i], games[j])
+	})
+	return games
 }
 
 func printGames(gameResults []GuessResults) {
@@ -162,11 +297,29 @@ func printGames(gameResults []GuessResults) {
 			}
 			fmt.Printf("%d ", numberGuessesAtCount)
 		}
+		fmt.Printf("failed:%d win:%.4f", gameResult.Failed, gameResult.WinRate)
 		fmt.Println()
 	}
 }
 
-func simulate(globalConfig GlobalConfiguration, oneGame bool, replaceFirst bool, firstWordsStrings []string, solutionStrings []string) {
+type SimulateConfiguration struct {
+	oneGame      bool
+	replaceFirst bool
+	maxGuesses   int    // games not solved in this many guesses are failures
+	format       string // format of the exported summary: csv or markdown
+	output       string // file for the exported summary, empty for stdout
+	checkpoint   string // file name of the checkpoint, empty for no checkpoint
+	resume       bool   // skip the initial guesses that are already in the checkpoint
+	adversarial  bool   // play against wordle.AdversarialHost instead of each solution
+	tieBreak     string // tie break of the adversarial host, see wordle.ParseTieBreak
+	share        bool   // print the share text of each game
+	number       int    // puzzle number of the share text, 0 for none
+	hardMode     bool   // the share text of the games that follow the hard mode rules is marked
+	tileSet      wordle.TileSet
+}
+
+func simulate(ctx context.Context, globalConfig GlobalConfiguration, simConfig SimulateConfiguration, firstWordsStrings []string, solutionStrings []string) error {
+	oneGame := simConfig.oneGame
 	d := globalConfig.dictionary
 	solutions := d.WordlistEmpty()
 	if len(solutionStrings) == 0 {
@@ -216,19 +369,72 @@ func simulate(globalConfig GlobalConfiguration, oneGame bool, replaceFirst bool,
 		}
 	}
 
-	summary := make([]map[int][]Game, len(initialGuesesList))
+	if simConfig.adversarial {
+		if len(solutionStrings) > 0 {
+			return cli.Exit("solutions can not be used with --adversarial, the host chooses the answers", 1)
+		}
+		return simulateAdversarial(ctx, d, simConfig, initialGuesesList)
+	}
+
+	var checkpoint *Checkpoint
+	if simConfig.resume && simConfig.checkpoint == "" {
+		return cli.Exit("resume needs a --checkpoint file", 1)
+	}
+	if simConfig.checkpoint != "" {
+		var err error
+		if checkpoint, err = OpenCheckpoint(simConfig.checkpoint, simConfig.resume, SolutionsFingerprint(d, solutions), simConfig.maxGuesses); err != nil {
+			return cli.Exit("checkpoint: "+err.Error(), 1)
+		}
+		defer checkpoint.Close()
+	}
+
+	summary := make([]map[int][]Game, 0, len(initialGuesesList))
+	interrupted := false
 	for outerLoopCount, initialGuesses := range initialGuesesList {
+		if checkpoint != nil {
+			if sortedGames, ok := checkpoint.Completed(d, d.WordSliceToStrings(initialGuesses)); ok {
+				fmt.Println("resumed from checkpoint:", d.WordSliceToStrings(initialGuesses))
+				summary = append(summary, sortedGames)
+				continue
+			}
+		}
 		sortedGames := make(map[int][]Game)
 		fmt.Println("outer loop count, limit:", outerLoopCount, len(initialGuesesList), d.WordSliceToStrings(initialGuesses))
 		for solutionCount, solution := range solutions.Range {
-			guesses := wordle.SimulateOneGameGivenFirstWord(d, solution, initialGuesses)
+			if ctx.Err() != nil {
+				interrupted = true
+				break
+			}
+			guesses, solved := wordle.SimulateOneGame(d, solution, initialGuesses, simConfig.maxGuesses)
 			fmt.Print(solutionCount, solutions.Len(), " ", d.String(solution), ":")
 			for _, guess := range guesses {
 				fmt.Print(" ", d.String(guess))
 			}
+			if !solved {
+				fmt.Print(" failed")
+			}
 			fmt.Println()
+			if simConfig.share {
+				answers := solutionAnswers(d, solution, guesses)
+				hardMode := simConfig.hardMode && wordle.IsHardMode(d.WordSliceToStrings(guesses), answers)
+				fmt.Println(wordle.ShareText(simConfig.number, answers, solved, simConfig.maxGuesses, hardMode, simConfig.tileSet))
+			}
 
-			sortedGames[len(guesses)] = append(sortedGames[len(guesses)], Game{solution, guesses})
+			numberGuesses := len(guesses)
+			if !solved {
+				numberGuesses = FAILED
+			}
+			sortedGames[numberGuesses] = append(sortedGames[numberGuesses], Game{solution, guesses})
+		}
+		if interrupted {
+			// the games for these initial guesses are incomplete, they are simulated again on resume
+			fmt.Println("interrupted:", d.WordSliceToStrings(initialGuesses))
+			break
+		}
+		if checkpoint != nil {
+			if err := checkpoint.Write(d, d.WordSliceToStrings(initialGuesses), sortedGames); err != nil {
+				return cli.Exit("checkpoint: "+err.Error(), 1)
+			}
 		}
 		fmt.Println(d.String(initialGuesses[0]), "---------------------")
 
@@ -249,12 +455,21 @@ func simulate(globalConfig GlobalConfiguration, oneGame bool, replaceFirst bool,
 				fmt.Println()
 			}
 		}
-		summary[outerLoopCount] = sortedGames
+		summary = append(summary, sortedGames)
+	}
+	games := outputFinalSummary(d, summary, simConfig.maxGuesses)
+	if simConfig.format != "" && simConfig.format != "text" {
+		if err := exportSummary(games, simConfig.maxGuesses, simConfig.format, simConfig.output); err != nil {
+			return cli.Exit("summary: "+err.Error(), 1)
+		}
 	}
-	outputFinalSummary(d, summary)
-	if replaceFirst {
+	if interrupted {
+		return cli.Exit("interrupted, use --resume to continue from the checkpoint", 130)
+	}
+	if simConfig.replaceFirst {
 		replaceFirstFiles(d, summary)
 	}
+	return nil
 }
 
 func first(globalConfig GlobalConfiguration) {
@@ -280,11 +495,63 @@ type GlobalConfiguration struct {
 	progress   bool
 }
 
-func globalCofiguration(count int, progress bool) GlobalConfiguration {
-	if count == 0 {
-		count = len(wordle.SortedWordleDictionary())
+// DictionarySource is where the words of the dictionary come from, the built in english words by default
+type DictionarySource struct {
+	words       []string // nil for the built in words
+	alphabet    *wordle.Alphabet
+	frequencies map[string]float64 // nil when all of the words are equally likely solutions
+}
+
+// loadDictionarySource reads and normalizes the word file for the alphabet, an empty file name is the built in words.
+// The frequency file is optional.
+func loadDictionarySource(wordsFile string, alphabetName string, frequencyFile string) (DictionarySource, error) {
+	alphabet, ok := wordle.AlphabetByName(alphabetName)
+	if !ok {
+		return DictionarySource{}, cli.Exit("alphabet must be one of: "+wordle.AlphabetNames(), 1)
 	}
-	dictionary := wordle.NewDictionary(wordle.SortedWordleDictionary()[0:count])
+	source := DictionarySource{alphabet: alphabet}
+	if frequencyFile != "" {
+		frequencies, err := readFrequencyFile(alphabet, frequencyFile)
+		if err != nil {
+			return source, cli.Exit(err.Error(), 1)
+		}
+		source.frequencies = frequencies
+	}
+	if wordsFile == "" {
+		return source, nil
+	}
+	lines, err := readWordFile(wordsFile)
+	if err != nil {
+		return source, cli.Exit(err.Error(), 1)
+	}
+	words, dropped := alphabet.NormalizeWords(lines)
+	if len(dropped) > 0 {
+		return source, cli.Exit(fmt.Sprintf("%s: %d words are not 5 letters of the %s alphabet, see wdl dict check", wordsFile, len(dropped), alphabet.Name), 1)
+	}
+	if len(words) == 0 || len(words) > bitset.BITS {
+		return source, cli.Exit(fmt.Sprintf("%s: %d words, must have 1 to %d", wordsFile, len(words), bitset.BITS), 1)
+	}
+	source.words = words
+	return source, nil
+}
+
+func globalCofiguration(count int, progress bool, source DictionarySource) GlobalConfiguration {
+	words := source.words
+	if words == nil {
+		words = wordle.SortedWordleDictionary()
+	}
+	alphabet := source.alphabet
+	if alphabet == nil {
+		alphabet = wordle.English
+	}
+	if count == 0 || count > len(words) {
+		count = len(words)
+	}
+	var weights []int
+	if source.frequencies != nil {
+		weights = wordle.ScaleWeights(words[0:count], source.frequencies)
+	}
+	dictionary := wordle.NewWeightedDictionary(words[0:count], alphabet, weights)
 	return GlobalConfiguration{
 		dictionary: dictionary,
 		progress:   progress,
@@ -296,9 +563,12 @@ func main() {
 	progress := false
 	profile := false
 	firstWord := ""
+	wordsFile := ""
+	alphabetName := wordle.English.Name
+	frequencyFile := ""
+	source := DictionarySource{}
 	// command specific flags
-	simulateOneGame := false
-	simulateReplace := false
+	simConfig := SimulateConfiguration{}
 	cmd := &cli.Command{
 		Name:  "wdl",
 		Usage: "wordle",
@@ -330,13 +600,60 @@ func main() {
 				Usage:       "first word to guess, default is 'raise', only used with sim command",
 				Destination: &firstWord,
 			},
+			&cli.StringFlag{
+				Name:        "words",
+				Value:       "",
+				Usage:       "file of words, one per line, instead of the built in english words",
+				Destination: &wordsFile,
+			},
+			&cli.StringFlag{
+				Name:        "alphabet",
+				Value:       wordle.English.Name,
+				Usage:       "letters of the words, accented letters outside of the alphabet are folded: " + wordle.AlphabetNames(),
+				Destination: &alphabetName,
+			},
+			&cli.StringFlag{
+				Name:        "frequencies",
+				Value:       "",
+				Usage:       "file of word frequencies, a word and a number per line, the solver favours the more frequent solutions",
+				Destination: &frequencyFile,
+			},
+		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			var err error
+			source, err = loadDictionarySource(wordsFile, alphabetName, frequencyFile)
+			return ctx, err
 		},
 		Commands: []*cli.Command{
 			{
 				Name: "play",
 				Usage: `play a game of wordle against the by entering pairs of [guess answer]...
 				https://www.nytimes.com/games/wordle/index.html
+				use ? in the answer for a tile with an unknown colour: raise r?gry
+				or paste the share grid and type the guesses: play --grid "$(pbpaste)" raise clout
 				`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "lies",
+						Value: 0,
+						Usage: "number of tiles in each answer with the wrong colour, 1 for Fibble",
+					},
+					&cli.StringFlag{
+						Name:  "grid",
+						Value: "",
+						Usage: "share grid of 🟩🟨⬛ rows for the answers, the arguments are just the guesses, - reads stdin",
+					},
+					&cli.BoolFlag{
+						Name:  "exclude-past",
+						Usage: "the solutions of the puzzles in the archive before --as-of are not possible words",
+					},
+					archiveFlag(),
+					&cli.StringFlag{
+						Name:  "as-of",
+						Value: time.Now().Format(HISTORY_DATE),
+						Usage: "date of the puzzle being played, see --exclude-past",
+					},
+				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 
 					if profile {
@@ -344,14 +661,270 @@ func main() {
 						defer def()
 					}
 
-					if cmd.NArg()%2 != 0 {
+					args := cmd.Args().Slice()
+					if grid := cmd.String("grid"); grid != "" {
+						var err error
+						if args, err = gridPairs(grid, args); err != nil {
+							return err
+						}
+					}
+					if len(args)%2 != 0 {
 						return cli.Exit("must have pairs of guess answer", 1)
-					} else if cmd.NArg() < 2 {
+					} else if len(args) < 2 {
 						return cli.Exit("must have at least one guess answer", 2)
-					} else {
-						playWordle(globalCofiguration(count, progress), cmd.Args().Slice())
 					}
-					return nil
+					playConfig := PlayConfiguration{lies: int(cmd.Int("lies")), asOf: cmd.String("as-of")}
+					if playConfig.lies < 0 || playConfig.lies > 5 {
+						return cli.Exit("lies must be from 0 to 5", 1)
+					}
+					if _, err := time.Parse(HISTORY_DATE, playConfig.asOf); err != nil {
+						return cli.Exit("as-of must be like "+HISTORY_DATE, 1)
+					}
+					if cmd.Bool("exclude-past") {
+						var err error
+						if playConfig.past, err = readArchive(source.alphabet, cmd.String("archive")); err != nil {
+							return cli.Exit(err.Error(), 1)
+						}
+					}
+					return playWordle(globalCofiguration(count, progress, source), playConfig, args)
+				},
+			},
+			{
+				Name: "explain",
+				Usage: `explain [guess answer]...
+				List the best next guesses for the board with the expected and worst case number of guesses,
+				the number of answers (buckets), the entropy, the largest bucket and if the guess is a possible word.
+				`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "top",
+						Value:   10,
+						Aliases: []string{"n"},
+						Usage:   "number of guesses to list",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if profile {
+						def := cpuProfile()
+						defer def()
+					}
+					if cmd.Int("top") < 1 {
+						return cli.Exit("top must be at least 1", 1)
+					}
+					return explain(globalCofiguration(count, progress, source), cmd.Int("top"), cmd.Args().Slice())
+				},
+			},
+			{
+				Name:  "dict",
+				Usage: "check, normalize and compare word list files with one word per line",
+				Commands: []*cli.Command{
+					{
+						Name:      "check",
+						Usage:     "report words that are not 5 lower case letters of the alphabet, duplicates and words out of order",
+						ArgsUsage: "<file>",
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() != 1 {
+								return cli.Exit("must have one word list file", 1)
+							}
+							return dictCheck(source.alphabet, cmd.Args().First())
+						},
+					},
+					{
+						Name:      "normalize",
+						Usage:     "write the sorted unique words in lower case with accents folded, dropping the words that are not 5 letters",
+						ArgsUsage: "<file>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Value:   "",
+								Usage:   "file for the normalized words, default is stdout",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() != 1 {
+								return cli.Exit("must have one word list file", 1)
+							}
+							return dictNormalize(source.alphabet, cmd.Args().First(), cmd.String("output"))
+						},
+					},
+					{
+						Name:      "diff",
+						Usage:     "show the solutions added and removed and how the candidates for each saved opener change",
+						ArgsUsage: "<old file> <new file>",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "opener",
+								Usage: "opener to compare in addition to the ones in the saved directory",
+							},
+							&cli.StringFlag{
+								Name:  "saved",
+								Value: FIRST_DIR,
+								Usage: "directory of the openers saved by sim --replace",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() != 2 {
+								return cli.Exit("must have the old and new word list files", 1)
+							}
+							return dictDiff(source.alphabet, cmd.Args().Get(0), cmd.Args().Get(1), cmd.StringSlice("opener"), cmd.String("saved"))
+						},
+					},
+				},
+			},
+			{
+				Name:  "history",
+				Usage: "store the games you played in a JSON Lines file and print statistics of them",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "history",
+						Value: "history.jsonl",
+						Usage: "file of the games played, one JSON game per line",
+					},
+				},
+				Commands: []*cli.Command{
+					{
+						Name:      "import",
+						Usage:     "add the games of a CSV file with the columns date (2006-01-02), solution, guesses separated by spaces and an optional hard mode",
+						ArgsUsage: "<csv file>",
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() != 1 {
+								return cli.Exit("must have one CSV file", 1)
+							}
+							return historyImport(globalCofiguration(count, progress, source), cmd.String("history"), cmd.Args().First())
+						},
+					},
+					{
+						Name:      "add",
+						Usage:     "add one game, use --hard if it was played in hard mode",
+						ArgsUsage: "<date> <solution> <guess>...",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "hard",
+								Usage: "the game was played in hard mode",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return historyAdd(globalCofiguration(count, progress, source), cmd.String("history"), cmd.Bool("hard"), cmd.Args().Slice())
+						},
+					},
+					{
+						Name: "stats",
+						Usage: `print the skill loss and luck of each game replayed with the solver like review when it was added, the
+						guess distribution, the streaks and the average skill loss and luck`,
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "max-guesses",
+								Value: wordle.DefaultMaxGuesses,
+								Usage: "games not solved in this many guesses are counted as failed",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return historyStats(cmd.String("history"), int(cmd.Int("max-guesses")))
+						},
+					},
+				},
+			},
+			{
+				Name: "archive",
+				Usage: `archive <number> [guess]...
+				replay the past puzzle with the number from the archive file.  The solver plays it from the first word with
+				all of the words and without the solutions of the earlier puzzles, the guesses are reviewed like review`,
+				Flags: []cli.Flag{
+					archiveFlag(),
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					first := firstWord
+					if first == "" {
+						first = "raise"
+					}
+					return archive(globalCofiguration(count, progress, source), cmd.String("archive"), first, cmd.Args().Slice())
+				},
+			},
+			{
+				Name: "review",
+				Usage: `review [--worst-case] <solution> <guess>...
+				replay a game and show for each guess the candidates remaining, the solver's best guess and the expected
+				number of guesses of both, the skill loss of the guess played and the luck of the answer received.  Like explain
+				the first step searches the whole dictionary and is slow, use -count to try it out`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "worst-case",
+						Value: false,
+						Usage: "also show the most guesses the best and played guesses could need, slower",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if profile {
+						def := cpuProfile()
+						defer def()
+					}
+					return review(globalCofiguration(count, progress, source), cmd.Bool("worst-case"), cmd.Args().Slice())
+				},
+			},
+			{
+				Name: "deduce",
+				Usage: `deduce <gridfile>...
+				list the solutions where every row of the share grids in the files could be produced by some guess,
+				the most likely first.  A file of - is read from stdin.`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "top",
+						Value:   10,
+						Aliases: []string{"n"},
+						Usage:   "number of solutions to list",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg() == 0 {
+						return cli.Exit("must have at least one share grid file", 1)
+					}
+					return deduce(globalCofiguration(count, progress, source), int(cmd.Int("top")), cmd.Args().Slice())
+				},
+			},
+			{
+				Name: "stats",
+				Usage: `stats [guess answer]...
+				letter frequency overall and by position for the possible words, the positions that are not yet known
+				and the letters that split the possible words closest to half`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return stats(globalCofiguration(count, progress, source), cmd.Args().Slice())
+				},
+			},
+			{
+				Name: "grep",
+				Usage: `grep <pattern>
+				list the words matching a pattern of letters and ? for any letter, like s?a?e, outside of a game.
+				The words are from the built in words or the --words file.`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "has",
+						Usage: "letters in the word, a repeated letter is in the word at least that many times",
+					},
+					&cli.StringFlag{
+						Name:  "not",
+						Usage: "letters not in the word",
+					},
+					&cli.StringSliceFlag{
+						Name:  "min-count",
+						Usage: "letter=count, the word has at least count of the letter, like x=2",
+					},
+					&cli.BoolFlag{
+						Name:  "by-weight",
+						Usage: "list the most frequent words first with their weight, needs --frequencies",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg() != 1 {
+						return cli.Exit("must have one pattern", 1)
+					}
+					grepConfig := GrepConfiguration{
+						has:       cmd.String("has"),
+						not:       cmd.String("not"),
+						minCounts: cmd.StringSlice("min-count"),
+						byWeight:  cmd.Bool("by-weight"),
+					}
+					return grep(globalCofiguration(count, progress, source), cmd.Args().First(), grepConfig)
 				},
 			},
 			{
@@ -373,7 +946,7 @@ func main() {
 						Usage: `wdl sim -one --first abyss --first create --first canal cacao
 						play one game of wordle.  Use each of the first words in order for the initial guesses in the game.
 						Useful for finding performance problems for combinartions of guesses.`,
-						Destination: &simulateOneGame,
+						Destination: &simConfig.oneGame,
 					},
 					&cli.BoolFlag{
 						Name:  "replace",
@@ -382,18 +955,232 @@ func main() {
 						incompatible with the -one flag. Simulate game(s) of wordle and replace the contents in the saved/ directory.
 						has a file for each first word, first.json, containg the optimial play for the word.  First.json files will be used
 						to improve performance of the play command.`,
-						Destination: &simulateReplace,
+						Destination: &simConfig.replaceFirst,
+					},
+					&cli.StringFlag{
+						Name:        "checkpoint",
+						Value:       "",
+						Usage:       "new file that stores the games as the simulation for each first word finishes, like sim-checkpoint.jsonl",
+						Destination: &simConfig.checkpoint,
+					},
+					&cli.IntFlag{
+						Name:        "max-guesses",
+						Value:       wordle.DefaultMaxGuesses,
+						Usage:       "games not solved in this many guesses are counted as failed",
+						Destination: &simConfig.maxGuesses,
+					},
+					&cli.StringFlag{
+						Name:        "format",
+						Value:       "text",
+						Usage:       "also export the summary of each first word as text, csv or markdown",
+						Destination: &simConfig.format,
+					},
+					&cli.StringFlag{
+						Name:        "output",
+						Aliases:     []string{"o"},
+						Value:       "",
+						Usage:       "file for the csv or markdown summary, default is stdout",
+						Destination: &simConfig.output,
+					},
+					&cli.BoolFlag{
+						Name:        "share",
+						Value:       false,
+						Usage:       "print the share text of each game",
+						Destination: &simConfig.share,
+					},
+					tileSetFlag(),
+					puzzleNumberFlag(),
+					hardModeFlag("only the games where every guess uses the hints of the answers before it are marked in the share text"),
+					&cli.BoolFlag{
+						Name:        "adversarial",
+						Value:       false,
+						Usage:       "play one game for each first word against a host that keeps the largest group of possible words",
+						Destination: &simConfig.adversarial,
+					},
+					&cli.StringFlag{
+						Name:        "tie-break",
+						Value:       "first",
+						Usage:       "adversarial answer when groups are the same size: " + wordle.TieBreakNames(),
+						Destination: &simConfig.tieBreak,
+					},
+					&cli.BoolFlag{
+						Name:        "resume",
+						Value:       false,
+						Usage:       "continue the --checkpoint file, skip the first words already simulated for the same solutions and include their games in the summary",
+						Destination: &simConfig.resume,
 					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					firstWords := cmd.StringSlice("first")
 					solutions := cmd.Args().Slice()
+					var err error
+					if simConfig.tileSet, err = parseTileSetFlag(cmd); err != nil {
+						return err
+					}
+					simConfig.number, simConfig.hardMode = int(cmd.Int("number")), cmd.Bool("hard")
 					if profile {
 						def := cpuProfile()
 						defer def()
 					}
-					simulate(globalCofiguration(count, progress), simulateOneGame, simulateReplace, firstWords, solutions)
-					return nil
+					// stop cleanly on ^C, the completed first words are already in the checkpoint
+					ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+					defer stop()
+					return simulate(ctx, globalCofiguration(count, progress, source), simConfig, firstWords, solutions)
+				},
+			},
+			{
+				Name: "serve",
+				Usage: `serve --addr :8080
+				Serve the solver as JSON endpoints: POST /next, /explain and /simulate.
+				`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "addr",
+						Value: ":8080",
+						Usage: "address to listen on",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return serve(globalCofiguration(count, progress, source), cmd.String("addr"))
+				},
+			},
+			{
+				Name: "game",
+				Usage: `game [--seed n] [--adversarial [--tie-break first|fewest-greens|random]] [--number n] [--hard]
+				Guess a hidden word from the dictionary in six tries, then see how the solver would have done
+				starting with your first guess.  With --adversarial the host never commits to a word, like Absurdle,
+				each answer keeps as many words as possible.
+				`,
+				Flags: []cli.Flag{
+					&cli.Int64Flag{
+						Name:  "seed",
+						Value: 0,
+						Usage: "seed to pick the hidden word, 0 is a different word each time",
+					},
+					&cli.BoolFlag{
+						Name:  "adversarial",
+						Value: false,
+						Usage: "the host keeps the largest group of possible words after each guess",
+					},
+					&cli.StringFlag{
+						Name:  "tie-break",
+						Value: "first",
+						Usage: "adversarial answer when groups are the same size: " + wordle.TieBreakNames(),
+					},
+					tileSetFlag(),
+					puzzleNumberFlag(),
+					hardModeFlag("every guess must use the hints of the answers before it"),
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					tieBreak, ok := wordle.ParseTieBreak(cmd.String("tie-break"))
+					if !ok {
+						return cli.Exit("tie-break must be one of: "+wordle.TieBreakNames(), 1)
+					}
+					tileSet, err := parseTileSetFlag(cmd)
+					if err != nil {
+						return err
+					}
+					gameConfig := GameConfiguration{seed: cmd.Int64("seed"), adversarial: cmd.Bool("adversarial"), tieBreak: tieBreak, tileSet: tileSet, number: int(cmd.Int("number")), hardMode: cmd.Bool("hard")}
+					return game(globalCofiguration(count, progress, source), gameConfig, os.Stdin, os.Stdout)
+				},
+			},
+			{
+				Name:  "multi",
+				Usage: "multi-board variants, Dordle (--boards 2), Quordle (--boards 4) and Octordle (--boards 8)",
+				Commands: []*cli.Command{
+					{
+						Name: "play",
+						Usage: `multi play --boards 2 [guess answer1 answer2]...
+						play the boards by entering a guess followed by the answer of each board, use - for a board that
+						was solved by an earlier guess.  wdl multi play --boards 2 raise rrgry ggggg clout ryrrr -`,
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "boards",
+								Value: 4,
+								Usage: "number of boards",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							boards := int(cmd.Int("boards"))
+							if boards < 1 {
+								return cli.Exit("boards must be at least 1", 1)
+							}
+							return multiPlay(globalCofiguration(count, progress, source), boards, cmd.Args().Slice())
+						},
+					},
+					{
+						Name: "sim",
+						Usage: `multi sim --boards 4 --first raise [solution1 solution2 solution3 solution4]...
+						simulate games for each group of solutions, or --games random groups of solutions`,
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "boards",
+								Value: 4,
+								Usage: "number of boards",
+							},
+							&cli.StringSliceFlag{
+								Name:  "first",
+								Usage: "initial guesses, the solver chooses the rest",
+							},
+							&cli.IntFlag{
+								Name:  "games",
+								Value: 10,
+								Usage: "number of random games when no solutions are given",
+							},
+							&cli.Int64Flag{
+								Name:  "seed",
+								Value: 0,
+								Usage: "seed to pick the solutions of the random games, 0 is different each time",
+							},
+							&cli.IntFlag{
+								Name:  "max-guesses",
+								Value: 0,
+								Usage: "games not solved in this many guesses are counted as failed, 0 is boards+5",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							multiConfig := MultiSimulateConfiguration{
+								boards:     int(cmd.Int("boards")),
+								games:      int(cmd.Int("games")),
+								seed:       cmd.Int64("seed"),
+								maxGuesses: int(cmd.Int("max-guesses")),
+							}
+							if multiConfig.boards < 1 {
+								return cli.Exit("boards must be at least 1", 1)
+							}
+							ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+							defer stop()
+							return multiSim(ctx, globalCofiguration(count, progress, source), multiConfig, cmd.StringSlice("first"), cmd.Args().Slice())
+						},
+					},
+				},
+			},
+			{
+				Name: "web",
+				Usage: `web --addr :8080
+				Serve a browser ui: an assistant that suggests the next guess and a practice mode with a hidden word.
+				`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "addr",
+						Value: ":8080",
+						Usage: "address to listen on",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return web(globalCofiguration(count, progress, source), cmd.String("addr"))
+				},
+			},
+			{
+				Name: "rpc",
+				Usage: `rpc
+				Long running process that reads JSON-RPC 2.0 requests, one per line, from stdin and writes the
+				responses to stdout.  Methods: session.create, session.apply {session, guess, answer},
+				session.suggest {session}, session.candidates {session}, session.close {session},
+				explain {guesses, top} and simulate {solution, first, max_guesses}.
+				`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return rpc(globalCofiguration(count, progress, source), os.Stdin, os.Stdout)
 				},
 			},
 			{
@@ -406,7 +1193,7 @@ func main() {
 						def := cpuProfile()
 						defer def()
 					}
-					first(globalCofiguration(count, progress))
+					first(globalCofiguration(count, progress, source))
 					return nil
 				},
 			},
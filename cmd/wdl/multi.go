@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// SOLVED_BOARD is the colour string for a board that was solved by an earlier guess
+const SOLVED_BOARD = "-"
+
+// multiPlay with groups of a guess followed by the answer of each board
+func multiPlay(globalConfig GlobalConfiguration, boards int, args []string) error {
+	d := globalConfig.dictionary
+	groupLen := boards + 1
+	if len(args) == 0 || len(args)%groupLen != 0 {
+		return cli.Exit(fmt.Sprintf("must have groups of a guess and %d answers", boards), 1)
+	}
+	multiBoard := d.NewMultiBoard(boards)
+	for i := 0; i < len(args); i += groupLen {
+		guess, ok := d.Word(args[i])
+		if !ok {
+			return cli.Exit("guess not in dictionary: "+args[i], 1)
+		}
+		answers := make([]wordle.Answer, boards)
+		for board, answerString := range args[i+1 : i+groupLen] {
+			if answerString == SOLVED_BOARD {
+				if !multiBoard.Solved(board) {
+					return cli.Exit(fmt.Sprintf("board %d is not solved, %s needs an answer", board+1, args[i]), 1)
+				}
+				continue
+			}
+			if multiBoard.Solved(board) {
+				return cli.Exit(fmt.Sprintf("board %d is already solved, use %s", board+1, SOLVED_BOARD), 1)
+			}
+			answer, ok := wordle.StringToAnswer(answerString)
+			if !ok {
+				return cli.Exit("answer not in right format r,y,g like rrggy: "+answerString, 1)
+			}
+			answers[board] = answer
+		}
+		multiBoard.Apply(guess, answers)
+		for board := range boards {
+			if !multiBoard.Solved(board) && multiBoard.PossibleWords(board).Len() == 0 {
+				return cli.Exit(fmt.Sprintf("board %d: no words match the guess answer pairs", board+1), 3)
+			}
+		}
+	}
+	if multiBoard.Done() {
+		fmt.Println("all boards solved")
+		return nil
+	}
+	fmt.Println(d.String(multiBoard.NextGuess()) + ":")
+	for board := range boards {
+		if multiBoard.Solved(board) {
+			fmt.Printf("board %d: solved\n", board+1)
+		} else {
+			possibleWords := d.WordlistStrings(multiBoard.PossibleWords(board))
+			fmt.Printf("board %d: %d %s\n", board+1, len(possibleWords), strings.Join(possibleWords, " "))
+		}
+	}
+	return nil
+}
+
+type MultiSimulateConfiguration struct {
+	boards     int
+	games      int   // number of random games when no solutions are given
+	seed       int64 // picks the solutions of the random games, 0 is the time
+	maxGuesses int   // 0 is wordle.DefaultMultiMaxGuesses
+}
+
+// multiSim plays each group of solutions, or random groups, and summarizes the number of guesses
+func multiSim(ctx context.Context, globalConfig GlobalConfiguration, multiConfig MultiSimulateConfiguration, firstWordsStrings []string, solutionStrings []string) error {
+	d := globalConfig.dictionary
+	boards := multiConfig.boards
+	if boards > d.Len() {
+		return cli.Exit("more boards than words in the dictionary", 1)
+	}
+	maxGuesses := multiConfig.maxGuesses
+	if maxGuesses == 0 {
+		maxGuesses = wordle.DefaultMultiMaxGuesses(boards)
+	}
+	firstWords := []wordle.WordleWord{}
+	for _, firstWordString := range firstWordsStrings {
+		firstWord, ok := d.Word(firstWordString)
+		if !ok {
+			return cli.Exit("first word not in dictionary: "+firstWordString, 1)
+		}
+		firstWords = append(firstWords, firstWord)
+	}
+
+	games := [][]wordle.WordleWord{}
+	if len(solutionStrings) > 0 {
+		if len(solutionStrings)%boards != 0 {
+			return cli.Exit(fmt.Sprintf("must have groups of %d solutions", boards), 1)
+		}
+		for i := 0; i < len(solutionStrings); i += boards {
+			solutions := []wordle.WordleWord{}
+			for _, solutionString := range solutionStrings[i : i+boards] {
+				solution, ok := d.Word(solutionString)
+				if !ok {
+					return cli.Exit("solution not in dictionary: "+solutionString, 1)
+				}
+				solutions = append(solutions, solution)
+			}
+			games = append(games, solutions)
+		}
+	} else {
+		seed := multiConfig.seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		random := rand.New(rand.NewSource(seed))
+		for range multiConfig.games {
+			solutions := []wordle.WordleWord{}
+			for _, i := range random.Perm(d.Len())[0:boards] {
+				solutions = append(solutions, wordle.WordleWord(i))
+			}
+			games = append(games, solutions)
+		}
+	}
+
+	guessCount := make([]int, maxGuesses+1)
+	totalGuesses, solvedGames := 0, 0
+	for _, solutions := range games {
+		if ctx.Err() != nil {
+			return cli.Exit("interrupted", 130)
+		}
+		guesses, solved := wordle.SimulateMultiGame(d, solutions, firstWords, maxGuesses)
+		fmt.Print(strings.Join(d.WordSliceToStrings(solutions), ","), ":")
+		for _, guess := range guesses {
+			fmt.Print(" ", d.String(guess))
+		}
+		if !solved {
+			fmt.Print(" failed")
+		} else {
+			guessCount[len(guesses)]++
+			totalGuesses += len(guesses)
+			solvedGames++
+		}
+		fmt.Println()
+	}
+	average := 0.0 // average number of guesses for the games that were solved, 0 if none were solved
+	if solvedGames > 0 {
+		average = float64(totalGuesses) / float64(solvedGames)
+	}
+	fmt.Printf("games:%d average:%.4f failed:%d", len(games), average, len(games)-solvedGames)
+	for numberGuesses := boards; numberGuesses <= maxGuesses; numberGuesses++ {
+		fmt.Printf(" %d:%d", numberGuesses, guessCount[numberGuesses])
+	}
+	fmt.Println()
+	return nil
+}
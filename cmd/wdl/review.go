@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// review replays the guesses of a game with the solution and compares each guess with the solver's guess, the worst
+// cases are searched and printed when worstCase is true
+func review(globalConfig GlobalConfiguration, worstCase bool, args []string) error {
+	d := globalConfig.dictionary
+	if len(args) < 2 {
+		return cli.Exit("must have the solution and at least one guess", 1)
+	}
+	words := []wordle.WordleWord{}
+	for _, arg := range args {
+		word, ok := d.Word(arg)
+		if !ok {
+			return cli.Exit("word not in dictionary: "+arg, 1)
+		}
+		words = append(words, word)
+	}
+	solution, guesses := words[0], words[1:]
+	steps := d.ReviewGame(solution, guesses, worstCase)
+	if len(steps) < len(guesses) {
+		return cli.Exit(fmt.Sprintf("solved in %d, there are %d extra guesses", len(steps), len(guesses)-len(steps)), 1)
+	}
+	skillLoss, luck := 0.0, 0.0
+	for i, step := range steps {
+		fmt.Printf("%d %s %s candidates:%d best:%s %.3f played:%.3f skill loss:%.3f luck:%+.3f",
+			i+1, d.String(step.Played.Guess), step.Answer, step.Candidates.Len(),
+			d.String(step.Best.Guess), step.Best.Expected, step.Played.Expected, step.SkillLoss, step.Luck)
+		if worstCase {
+			fmt.Printf(" worst case best:%d played:%d", step.Best.WorstCase, step.Played.WorstCase)
+		}
+		fmt.Println()
+		skillLoss += step.SkillLoss
+		luck += step.Luck
+	}
+	if steps[len(steps)-1].Answer != wordle.AllGreenAnswer {
+		fmt.Println("not solved")
+	}
+	fmt.Printf("total skill loss:%.3f luck:%+.3f\n", skillLoss, luck)
+	return nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+	rpcContradiction  = -32000 // the guess/answer pairs leave no possible words, data is the contradiction
+)
+
+type RPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // no id is a notification, there is no response
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+type SessionParams struct {
+	Session string `json:"session"`
+	Guess   string `json:"guess"`
+	Answer  string `json:"answer"`
+}
+
+type SessionResult struct {
+	Session    string `json:"session"`
+	Candidates int    `json:"candidates"`
+}
+
+type CandidatesResult struct {
+	Candidates []string `json:"candidates"`
+}
+
+// rpcServer keeps the guess/answer pairs of each session, all sessions share the solver
+type rpcServer struct {
+	solver      *solver
+	sessions    map[string][]GuessAnswerJSON
+	nextSession int
+}
+
+func newRPCServer(s *solver) *rpcServer {
+	return &rpcServer{solver: s, sessions: make(map[string][]GuessAnswerJSON)}
+}
+
+// Run reads one request per line and writes one response per line until the input is closed
+func (r *rpcServer) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(out)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var request RPCRequest
+		var response RPCResponse
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			response = RPCResponse{Error: &RPCError{Code: rpcParseError, Message: err.Error()}}
+		} else if request.JSONRPC != "2.0" || request.Method == "" {
+			response = RPCResponse{ID: request.ID, Error: &RPCError{Code: rpcInvalidRequest, Message: "expecting jsonrpc 2.0 and a method"}}
+		} else {
+			result, rpcErr := r.call(request.Method, request.Params)
+			if request.ID == nil {
+				continue // notification
+			}
+			response = RPCResponse{ID: request.ID, Result: result, Error: rpcErr}
+		}
+		response.JSONRPC = "2.0"
+		if response.ID == nil {
+			response.ID = json.RawMessage("null")
+		}
+		if err := encoder.Encode(response); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// call runs one method, a panic is reported as an internal error instead of ending the server
+func (r *rpcServer) call(method string, rawParams json.RawMessage) (result any, rpcErr *RPCError) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result, rpcErr = nil, &RPCError{Code: rpcInternalError, Message: fmt.Sprint("internal error: ", recovered)}
+		}
+	}()
+	decode := func(params any) *RPCError {
+		if len(rawParams) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(rawParams, params); err != nil {
+			return &RPCError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	session := func(params *SessionParams) ([]GuessAnswerJSON, *RPCError) {
+		if rpcErr := decode(params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		guessAnswers, ok := r.sessions[params.Session]
+		if !ok {
+			return nil, &RPCError{Code: rpcInvalidParams, Message: "unknown session: " + params.Session}
+		}
+		return guessAnswers, nil
+	}
+
+	var params SessionParams
+	switch method {
+	case "session.create":
+		r.nextSession++
+		id := strconv.Itoa(r.nextSession)
+		r.sessions[id] = []GuessAnswerJSON{}
+		return SessionResult{Session: id, Candidates: r.solver.d.Len()}, nil
+	case "session.close":
+		if _, rpcErr := session(&params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		delete(r.sessions, params.Session)
+		return SessionResult{Session: params.Session}, nil
+	case "session.apply":
+		guessAnswers, rpcErr := session(&params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		applied := append(append([]GuessAnswerJSON{}, guessAnswers...), GuessAnswerJSON{Guess: params.Guess, Answer: params.Answer})
+		possibleWords, err := r.solver.candidates(applied)
+		if err != nil {
+			return rpcResult(nil, err)
+		}
+		r.sessions[params.Session] = applied
+		return SessionResult{Session: params.Session, Candidates: len(possibleWords)}, nil
+	case "session.suggest":
+		guessAnswers, rpcErr := session(&params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return rpcResult(r.solver.next(guessAnswers))
+	case "session.candidates":
+		guessAnswers, rpcErr := session(&params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		possibleWords, err := r.solver.candidates(guessAnswers)
+		if err != nil {
+			return rpcResult(nil, err)
+		}
+		return CandidatesResult{Candidates: possibleWords}, nil
+	case "explain":
+		explainParams := ExplainRequest{Top: 10}
+		if rpcErr := decode(&explainParams); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return rpcResult(r.solver.explain(explainParams.Guesses, explainParams.Top))
+	case "simulate":
+		var simulateParams SimulateRequest
+		if rpcErr := decode(&simulateParams); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return rpcResult(r.solver.simulate(simulateParams.Solution, simulateParams.First, simulateParams.MaxGuesses))
+	}
+	return nil, &RPCError{Code: rpcMethodNotFound, Message: "method not found: " + method}
+}
+
+func rpcResult(result any, err error) (any, *RPCError) {
+	var contradictionError *ContradictionError
+	if errors.As(err, &contradictionError) {
+		return nil, &RPCError{Code: rpcContradiction, Message: err.Error(), Data: contradictionError.Contradiction}
+	} else if err != nil {
+		return nil, &RPCError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+	return result, nil
+}
+
+// rpc serves JSON-RPC on stdin and stdout until stdin is closed
+func rpc(globalConfig GlobalConfiguration, in io.Reader, out io.Writer) error {
+	if err := newRPCServer(newSolver(globalConfig.dictionary)).Run(in, out); err != nil {
+		return fmt.Errorf("rpc: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCSession(t *testing.T) {
+	d := wordle.NewDictionary(wordle.SortedWordleDictionary()[0:200])
+	in := strings.NewReader(strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"session.create"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"session.apply","params":{"session":"1","guess":"about","answer":"grrrr"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"session.suggest","params":{"session":"1"}}`,
+		`{"jsonrpc":"2.0","method":"session.candidates","params":{"session":"1"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"session.apply","params":{"session":"1","guess":"about","answer":"ggggg"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"nope"}`,
+		`{"jsonrpc":"2.0","id":6,"method":"explain","params":{"top":0}}`,
+		`not json`,
+	}, "\n"))
+	out := &strings.Builder{}
+	assert.NoError(t, newRPCServer(newSolver(d)).Run(in, out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 7) // the notification has no response
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":{"session":"1","candidates":200}}`, lines[0])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":2,"result":{"session":"1","candidates":55}}`, lines[1])
+	assert.Contains(t, lines[2], `"guess":"agile"`)
+	assert.Contains(t, lines[3], `"code":-32000`)
+	assert.Contains(t, lines[4], `"code":-32601`)
+	assert.Contains(t, lines[5], `"top must be at least 1`)
+	assert.Contains(t, lines[6], `"id":null,"error":{"code":-32700`)
+}
+
+func TestRPCPanic(t *testing.T) {
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"session.create"}` + "\n" + `{"jsonrpc":"2.0","id":2,"method":"nope"}`)
+	out := &strings.Builder{}
+	assert.NoError(t, newRPCServer(&solver{}).Run(in, out)) // no dictionary, session.create panics
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"id":1,"error":{"code":-32603,"message":"internal error: `)
+	assert.Contains(t, lines[1], `"code":-32601`)
+}
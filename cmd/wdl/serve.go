@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type NextRequest struct {
+	Guesses []GuessAnswerJSON `json:"guesses"`
+}
+
+type ExplainRequest struct {
+	Guesses []GuessAnswerJSON `json:"guesses"`
+	Top     int               `json:"top"` // number of guesses to return, default 10
+}
+
+type SimulateRequest struct {
+	Solution   string   `json:"solution"`
+	First      []string `json:"first"`       // initial guesses, the solver chooses the rest
+	MaxGuesses int      `json:"max_guesses"` // default 6
+}
+
+type ErrorResponse struct {
+	Error         string             `json:"error"`
+	Contradiction *ContradictionJSON `json:"contradiction,omitempty"`
+}
+
+// newServeMux returns the handler for the JSON endpoints of wdl serve
+func newServeMux(s *solver) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /next", func(w http.ResponseWriter, r *http.Request) {
+		var request NextRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		result, err := s.next(request.Guesses)
+		writeResult(w, result, err)
+	})
+	mux.HandleFunc("POST /explain", func(w http.ResponseWriter, r *http.Request) {
+		request := ExplainRequest{Top: 10}
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		result, err := s.explain(request.Guesses, request.Top)
+		writeResult(w, result, err)
+	})
+	mux.HandleFunc("POST /simulate", func(w http.ResponseWriter, r *http.Request) {
+		var request SimulateRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		result, err := s.simulate(request.Solution, request.First, request.MaxGuesses)
+		writeResult(w, result, err)
+	})
+	return mux
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, request any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "bad request: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeResult(w http.ResponseWriter, result any, err error) {
+	var contradictionError *ContradictionError
+	if errors.As(err, &contradictionError) {
+		writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error(), Contradiction: &contradictionError.Contradiction})
+	} else if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	} else {
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// serve the solver over http until the server fails
+func serve(globalConfig GlobalConfiguration, addr string) error {
+	fmt.Println("listening on", addr)
+	return http.ListenAndServe(addr, newServeMux(newSolver(globalConfig.dictionary)))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/stretchr/testify/assert"
+)
+
+func testServer() *httptest.Server {
+	d := wordle.NewDictionary(wordle.SortedWordleDictionary()[0:200])
+	return httptest.NewServer(newServeMux(newSolver(d)))
+}
+
+func post(t *testing.T, server *httptest.Server, path string, body string) (int, string) {
+	response, err := http.Post(server.URL+path, "application/json", strings.NewReader(body))
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	return response.StatusCode, string(responseBody)
+}
+
+func TestServeNext(t *testing.T) {
+	server := testServer()
+	defer server.Close()
+	status, body := post(t, server, "/next", `{"guesses":[{"guess":"about","answer":"grrrr"}]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"candidates":["acrid",`)
+
+	status, body = post(t, server, "/next", `{"guesses":[{"guess":"about","answer":"ggggg"},{"guess":"about","answer":"rrrrr"}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, body, `"contradiction":`)
+
+	status, _ = post(t, server, "/next", `{"guesses":[{"guess":"zzzzz","answer":"rrrrr"}]}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestServeExplainSimulate(t *testing.T) {
+	server := testServer()
+	defer server.Close()
+	status, body := post(t, server, "/explain", `{"guesses":[{"guess":"about","answer":"grrrr"}],"top":3}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"worst_case":`)
+
+	status, body = post(t, server, "/explain", `{"guesses":[],"top":-1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "top must be at least 1")
+
+	status, body = post(t, server, "/simulate", `{"solution":"acorn","first":["about"]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"solved":true`)
+	assert.Contains(t, body, `"answers":["grgrr",`)
+}
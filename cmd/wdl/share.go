@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// gridPairs returns the guess answer pairs for the typed guesses and the rows of the share grid, a grid of - is
+// read from stdin
+func gridPairs(grid string, guesses []string) ([]string, error) {
+	if grid == "-" {
+		text, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, cli.Exit("share grid: "+err.Error(), 1)
+		}
+		grid = string(text)
+	}
+	answers, err := wordle.ParseShareGrid(grid)
+	if err != nil {
+		return nil, cli.Exit(err.Error(), 1)
+	}
+	if len(guesses) != len(answers) {
+		return nil, cli.Exit(fmt.Sprintf("must have one guess for each of the %d share grid rows, have %d", len(answers), len(guesses)), 1)
+	}
+	pairs := []string{}
+	for i, guess := range guesses {
+		pairs = append(pairs, guess, answers[i].String())
+	}
+	return pairs, nil
+}
+
+// solutionAnswers are the answers to each of the guesses for the solution
+func solutionAnswers(d *wordle.Dictionary, solution wordle.WordleWord, guesses []wordle.WordleWord) []wordle.Answer {
+	answers := []wordle.Answer{}
+	for _, guess := range guesses {
+		answers = append(answers, d.Answer(solution, guess))
+	}
+	return answers
+}
+
+// tileSetFlag is the --tiles flag of the commands that print share text
+func tileSetFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "tiles",
+		Value: wordle.TilesDark.String(),
+		Usage: "emoji of the share text: " + wordle.TileSetNames(),
+	}
+}
+
+// puzzleNumberFlag is the --number flag of the commands that print share text
+func puzzleNumberFlag() *cli.IntFlag {
+	return &cli.IntFlag{
+		Name:  "number",
+		Value: 0,
+		Usage: "puzzle number of the share text, 0 leaves it out",
+	}
+}
+
+// hardModeFlag is the --hard flag of the commands that print share text, usage is how the command checks hard mode
+func hardModeFlag(usage string) *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:  "hard",
+		Value: false,
+		Usage: "hard mode, " + usage,
+	}
+}
+
+func parseTileSetFlag(cmd *cli.Command) (wordle.TileSet, error) {
+	tileSet, ok := wordle.ParseTileSet(cmd.String("tiles"))
+	if !ok {
+		return tileSet, cli.Exit("tiles must be one of: "+wordle.TileSetNames(), 1)
+	}
+	return tileSet, nil
+}
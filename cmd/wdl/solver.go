@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/powellquiring/wordle/wordle"
+)
+
+// solver shares one dictionary, and the caches that warm up behind it, between requests.  The caches are not safe
+// for concurrent use so the dictionary is used by one request at a time.
+type solver struct {
+	mu sync.Mutex
+	d  *wordle.Dictionary
+}
+
+func newSolver(d *wordle.Dictionary) *solver {
+	return &solver{d: d}
+}
+
+type GuessAnswerJSON struct {
+	Guess  string `json:"guess"`
+	Answer string `json:"answer"`
+}
+
+type CorrectionJSON struct {
+	Pair          int    `json:"pair"`
+	Tile          int    `json:"tile"`
+	Guess         string `json:"guess"`
+	Answer        string `json:"answer"`
+	PossibleWords int    `json:"possible_words"`
+}
+
+type ContradictionJSON struct {
+	Pair        int              `json:"pair"`
+	Guess       string           `json:"guess"`
+	Answer      string           `json:"answer"`
+	Remaining   int              `json:"remaining"`
+	Tile        int              `json:"tile"`
+	Constraint  string           `json:"constraint"`
+	Corrections []CorrectionJSON `json:"corrections"`
+}
+
+// ContradictionError is returned when the guess/answer pairs leave no possible words
+type ContradictionError struct {
+	Contradiction ContradictionJSON
+}
+
+func (e *ContradictionError) Error() string {
+	return fmt.Sprintf("no words match the guess answer pairs, pair %d tile %d: %s", e.Contradiction.Pair+1, e.Contradiction.Tile+1, e.Contradiction.Constraint)
+}
+
+func contradictionJSON(contradiction wordle.Contradiction) ContradictionJSON {
+	ret := ContradictionJSON{
+		Pair:        contradiction.Pair,
+		Guess:       contradiction.GuessAnswer.Guess,
+		Answer:      contradiction.GuessAnswer.Answer,
+		Remaining:   contradiction.Remaining,
+		Tile:        contradiction.Tile,
+		Constraint:  contradiction.Constraint,
+		Corrections: []CorrectionJSON{},
+	}
+	for _, correction := range contradiction.Corrections {
+		ret.Corrections = append(ret.Corrections, CorrectionJSON{
+			Pair:          correction.Pair,
+			Tile:          correction.Tile,
+			Guess:         correction.GuessAnswer.Guess,
+			Answer:        correction.GuessAnswer.Answer,
+			PossibleWords: correction.PossibleWords,
+		})
+	}
+	return ret
+}
+
+func toGuessAnswers(d *wordle.Dictionary, guessAnswersJSON []GuessAnswerJSON) []wordle.GuessAnswer {
+	guessAnswers := []wordle.GuessAnswer{}
+	for _, guessAnswerJSON := range guessAnswersJSON {
+		guessAnswers = append(guessAnswers, wordle.GuessAnswer{Guess: d.Alphabet().Normalize(guessAnswerJSON.Guess), Answer: guessAnswerJSON.Answer})
+	}
+	return guessAnswers
+}
+
+// possibleWords checks the guess/answer pairs and returns the words that match them
+func (s *solver) possibleWords(guessAnswersJSON []GuessAnswerJSON) (*wordle.WordList, error) {
+	guessAnswers := toGuessAnswers(s.d, guessAnswersJSON)
+	for _, guessAnswer := range guessAnswers {
+		if err := checkGuessAnswer(s.d, guessAnswer); err != nil {
+			return nil, err
+		}
+	}
+	if contradiction, ok := s.d.FindContradiction(guessAnswers); ok {
+		return nil, &ContradictionError{contradictionJSON(contradiction)}
+	}
+	return s.d.PossibleWords(guessAnswers), nil
+}
+
+// candidates returns the possible words for the guess/answer pairs
+func (s *solver) candidates(guessAnswers []GuessAnswerJSON) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	possibleWords, err := s.possibleWords(guessAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return s.d.WordlistStrings(possibleWords), nil
+}
+
+type NextResult struct {
+	Guess      string   `json:"guess"`
+	Candidates []string `json:"candidates"`
+}
+
+// next returns the best guess and the possible words for the guess/answer pairs
+func (s *solver) next(guessAnswers []GuessAnswerJSON) (NextResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.possibleWords(guessAnswers); err != nil {
+		return NextResult{}, err
+	}
+	nextGuess, possibleWords := s.d.PlayWorldReturnPossible(toGuessAnswers(s.d, guessAnswers))
+	return NextResult{Guess: s.d.String(nextGuess), Candidates: s.d.WordlistStrings(possibleWords)}, nil
+}
+
+type GuessReportJSON struct {
+	Guess         string  `json:"guess"`
+	Expected      float64 `json:"expected"`
+	WorstCase     int     `json:"worst_case"`
+	Buckets       int     `json:"buckets"`
+	Entropy       float64 `json:"entropy"`
+	LargestBucket int     `json:"largest_bucket"`
+	Candidate     bool    `json:"candidate"`
+}
+
+type ExplainResult struct {
+	Candidates int               `json:"candidates"`
+	Guesses    []GuessReportJSON `json:"guesses"`
+}
+
+// explain returns the top guesses for the guess/answer pairs, see wdl explain
+func (s *solver) explain(guessAnswers []GuessAnswerJSON, top int) (ExplainResult, error) {
+	if top < 1 {
+		return ExplainResult{}, fmt.Errorf("top must be at least 1: %d", top)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	possibleWords, err := s.possibleWords(guessAnswers)
+	if err != nil {
+		return ExplainResult{}, err
+	}
+	ret := ExplainResult{Candidates: possibleWords.Len(), Guesses: []GuessReportJSON{}}
+	for _, report := range s.d.ExplainGuesses(possibleWords, top) {
+		ret.Guesses = append(ret.Guesses, GuessReportJSON{
+			Guess:         s.d.String(report.Guess),
+			Expected:      report.Expected,
+			WorstCase:     report.WorstCase,
+			Buckets:       report.Buckets,
+			Entropy:       report.Entropy,
+			LargestBucket: report.LargestBucket,
+			Candidate:     report.Candidate,
+		})
+	}
+	return ret, nil
+}
+
+type SimulateResult struct {
+	Solution string   `json:"solution"`
+	Guesses  []string `json:"guesses"`
+	Answers  []string `json:"answers"`
+	Solved   bool     `json:"solved"`
+}
+
+// simulate plays one game for the solution starting with the first words
+func (s *solver) simulate(solutionString string, firstStrings []string, maxGuesses int) (SimulateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	solution, ok := s.d.Word(solutionString)
+	if !ok {
+		return SimulateResult{}, fmt.Errorf("solution not in dictionary: %s", solutionString)
+	}
+	first := []wordle.WordleWord{}
+	for _, firstString := range firstStrings {
+		word, ok := s.d.Word(firstString)
+		if !ok {
+			return SimulateResult{}, fmt.Errorf("first word not in dictionary: %s", firstString)
+		}
+		first = append(first, word)
+	}
+	if maxGuesses <= 0 {
+		maxGuesses = wordle.DefaultMaxGuesses
+	}
+	guesses, solved := wordle.SimulateOneGame(s.d, solution, first, maxGuesses)
+	ret := SimulateResult{Solution: solutionString, Guesses: s.d.WordSliceToStrings(guesses), Answers: []string{}, Solved: solved}
+	for _, guess := range guesses {
+		ret.Answers = append(ret.Answers, s.d.Answer(solution, guess).String())
+	}
+	return ret, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/urfave/cli/v3"
+)
+
+// stats prints the letter statistics of the possible words for the guess/answer pairs
+func stats(globalConfig GlobalConfiguration, answers []string) error {
+	d := globalConfig.dictionary
+	guessAnswers, err := parseGuessAnswers(d, answers)
+	if err != nil {
+		return err
+	}
+	if contradiction, ok := d.FindContradiction(guessAnswers); ok {
+		printContradiction(contradiction)
+		return cli.Exit("no words match the guess answer pairs", 3)
+	}
+	letterStats := d.LetterStats(d.PossibleWords(guessAnswers))
+	fmt.Println("possible words:", letterStats.Words)
+	fmt.Println("letters:", letterFrequencies(letterStats.Letters))
+	fmt.Println("repeated letters:", letterFrequencies(letterStats.Repeated))
+	for position, frequencies := range letterStats.Positions {
+		fmt.Printf("position %d: %s\n", position+1, letterFrequencies(frequencies))
+	}
+	unresolved := []string{}
+	for _, position := range letterStats.Unresolved {
+		unresolved = append(unresolved, fmt.Sprint(position+1))
+	}
+	fmt.Println("unresolved positions:", strings.Join(unresolved, " "))
+	fmt.Println("best splitting letters:", letterFrequencies(letterStats.Splitters))
+	return nil
+}
+
+func letterFrequencies(frequencies []wordle.LetterFrequency) string {
+	ret := []string{}
+	for _, frequency := range frequencies {
+		ret = append(ret, fmt.Sprintf("%c:%d", frequency.Letter, frequency.Words))
+	}
+	return strings.Join(ret, " ")
+}
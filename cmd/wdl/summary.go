@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// exportSummary writes the results for each first word as a csv or markdown table
+func exportSummary(games []GuessResults, maxGuesses int, format string, output string) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	switch format {
+	case "csv":
+		return writeSummaryCSV(w, games, maxGuesses)
+	case "markdown", "md":
+		return writeSummaryMarkdown(w, games, maxGuesses)
+	default:
+		return fmt.Errorf("unknown format %s, expecting text, csv or markdown", format)
+	}
+}
+
+func summaryHeader(maxGuesses int) []string {
+	header := []string{"first", "games", "average", "win_rate", "failed"}
+	for numberGuesses := 1; numberGuesses <= maxGuesses; numberGuesses++ {
+		header = append(header, strconv.Itoa(numberGuesses))
+	}
+	return header
+}
+
+func summaryRow(game GuessResults, maxGuesses int) []string {
+	games := game.Failed
+	for _, numberGuessesAtCount := range game.GuessCount {
+		games += numberGuessesAtCount
+	}
+	row := []string{
+		game.Guess,
+		strconv.Itoa(games),
+		strconv.FormatFloat(game.Average, 'f', 4, 64),
+		strconv.FormatFloat(game.WinRate, 'f', 4, 64),
+		strconv.Itoa(game.Failed),
+	}
+	for numberGuesses := 1; numberGuesses <= maxGuesses; numberGuesses++ {
+		row = append(row, strconv.Itoa(game.GuessCount[numberGuesses]))
+	}
+	return row
+}
+
+func writeSummaryCSV(w io.Writer, games []GuessResults, maxGuesses int) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(summaryHeader(maxGuesses)); err != nil {
+		return err
+	}
+	for _, game := range games {
+		if err := csvWriter.Write(summaryRow(game, maxGuesses)); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func writeSummaryMarkdown(w io.Writer, games []GuessResults, maxGuesses int) error {
+	header := summaryHeader(maxGuesses)
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---:"
+	}
+	separator[0] = "---"
+	lines := []string{
+		"| " + strings.Join(header, " | ") + " |",
+		"| " + strings.Join(separator, " | ") + " |",
+	}
+	for _, game := range games {
+		lines = append(lines, "| "+strings.Join(summaryRow(game, maxGuesses), " | ")+" |")
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeGames(t *testing.T) {
+	d := globalCofiguration(200, false, DictionarySource{}).dictionary
+	words := d.StringsToWordSlice([]string{"about", "acrid", "admin"})
+	failed := map[int][]Game{FAILED: {{Solution: words[0], Guesses: words[1:2]}}}
+	solved := map[int][]Game{2: {{Solution: words[0], Guesses: []wordle.WordleWord{words[2], words[0]}}}}
+	games := summarizeGames(d, []map[int][]Game{failed, solved}, 6)
+	assert.Equal(t, 0.0, games[0].Average)
+	assert.Equal(t, 0.0, games[0].WinRate)
+	assert.Equal(t, 2.0, games[1].Average)
+	assert.True(t, byAverage(games[1], games[0]))
+	assert.False(t, byAverage(games[0], games[1]))
+}
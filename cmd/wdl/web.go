@@ -0,0 +1,129 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/powellquiring/wordle/wordle"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// randomSolution picks a word from the dictionary, a seed of 0 picks a different word each time
+func randomSolution(d *wordle.Dictionary, seed int64) wordle.WordleWord {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return wordle.WordleWord(rand.New(rand.NewSource(seed)).Intn(d.Len()))
+}
+
+type PracticeNewRequest struct {
+	Seed int64 `json:"seed"` // 0 for a random word
+}
+
+type PracticeGuessRequest struct {
+	ID    string `json:"id"`
+	Guess string `json:"guess"`
+}
+
+type PracticeResult struct {
+	ID         string `json:"id"`
+	MaxGuesses int    `json:"max_guesses"`
+	Answer     string `json:"answer,omitempty"`
+	Solved     bool   `json:"solved"`
+	Over       bool   `json:"over"`
+	Solution   string `json:"solution,omitempty"` // only shown when the game is over
+}
+
+type practiceGame struct {
+	solution wordle.WordleWord
+	guesses  int
+}
+
+// practiceGames are the games of the web practice mode, the solution is kept on the server
+type practiceGames struct {
+	solver *solver
+	mu     sync.Mutex
+	games  map[string]*practiceGame
+	nextID int
+}
+
+func newPracticeGames(s *solver) *practiceGames {
+	return &practiceGames{solver: s, games: make(map[string]*practiceGame)}
+}
+
+func (p *practiceGames) start(seed int64) PracticeResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nextID++
+	id := strconv.Itoa(p.nextID)
+	p.games[id] = &practiceGame{solution: randomSolution(p.solver.d, seed)}
+	return PracticeResult{ID: id, MaxGuesses: wordle.DefaultMaxGuesses}
+}
+
+func (p *practiceGames) guess(id string, guessString string) (PracticeResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	game, ok := p.games[id]
+	if !ok {
+		return PracticeResult{}, fmt.Errorf("unknown game: %s", id)
+	}
+	guess, ok := p.solver.d.Word(guessString)
+	if !ok {
+		return PracticeResult{}, fmt.Errorf("not in word list: %s", guessString)
+	}
+	p.solver.mu.Lock()
+	answer := p.solver.d.Answer(game.solution, guess)
+	p.solver.mu.Unlock()
+	game.guesses++
+	ret := PracticeResult{ID: id, MaxGuesses: wordle.DefaultMaxGuesses, Answer: answer.String(), Solved: guess == game.solution}
+	if ret.Solved || game.guesses >= wordle.DefaultMaxGuesses {
+		ret.Over = true
+		ret.Solution = p.solver.d.String(game.solution)
+		delete(p.games, id)
+	}
+	return ret, nil
+}
+
+// newWebMux serves the embedded single page app at / and the solver at /api/
+func newWebMux(s *solver) *http.ServeMux {
+	api := newServeMux(s)
+	practice := newPracticeGames(s)
+	api.HandleFunc("POST /practice/new", func(w http.ResponseWriter, r *http.Request) {
+		var request PracticeNewRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		writeResult(w, practice.start(request.Seed), nil)
+	})
+	api.HandleFunc("POST /practice/guess", func(w http.ResponseWriter, r *http.Request) {
+		var request PracticeGuessRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		result, err := practice.guess(request.ID, request.Guess)
+		writeResult(w, result, err)
+	})
+
+	static, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic("embedded web files: " + err.Error())
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", api))
+	mux.Handle("/", http.FileServerFS(static))
+	return mux
+}
+
+// web serves the browser ui until the server fails
+func web(globalConfig GlobalConfiguration, addr string) error {
+	fmt.Println("listening on", addr)
+	return http.ListenAndServe(addr, newWebMux(newSolver(globalConfig.dictionary)))
+}
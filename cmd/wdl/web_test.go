@@ -0,0 +1,40 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/powellquiring/wordle/wordle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeb(t *testing.T) {
+	d := wordle.NewDictionary(wordle.SortedWordleDictionary()[0:200])
+	server := httptest.NewServer(newWebMux(newSolver(d)))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/")
+	assert.NoError(t, err)
+	page, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, string(page), "<title>wdl</title>")
+
+	status, body := post(t, server, "/api/next", `{"guesses":[{"guess":"about","answer":"grrrr"}]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"candidates":["acrid",`)
+
+	status, body = post(t, server, "/api/practice/new", `{"seed":1}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"id":"1"`)
+	solution := d.String(randomSolution(d, 1))
+	status, body = post(t, server, "/api/practice/guess", `{"id":"1","guess":"`+solution+`"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"answer":"ggggg"`)
+	assert.Contains(t, body, `"over":true`)
+
+	status, _ = post(t, server, "/api/practice/guess", `{"id":"1","guess":"about"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -152,32 +152,35 @@ type GuessAnswer struct {
 }
 
 // play wordle against the computer providing the current board state
-// return the next best answer
-func PlayWorldReturnPossible(allWordleWords []WordleWord, guessAnswers []GuessAnswer) (WordleWord, []WordleWord) {
+// return the next best answer, the cache keeps the matchers of the search for the answer
+func PlayWorldReturnPossible(cache *MatcherCache, allWordleWords []WordleWord, guessAnswers []GuessAnswer) (WordleWord, []WordleWord) {
 	possibleAnswers := allWordleWords
 
 	for _, guessAnswer := range guessAnswers {
-		game := NewWordleMatcher(possibleAnswers)
+		game := cache.Matcher(possibleAnswers)
 		possibleAnswers = game.Matching(guessAnswer.Guess, guessAnswer.Answer)
 	}
 	//ret := NextGuess(allWordleWords, possibleAnswers)
-	ret := NextGuess1(allWordleWords, possibleAnswers)
+	ret := NextGuess1(cache, allWordleWords, possibleAnswers)
 	return ret, possibleAnswers
 }
 
+// PlayWordle returns the next best answer, the matchers made for the game are released when it returns
 func PlayWordle(allWordleWords []WordleWord, guessAnswers []GuessAnswer) WordleWord {
-	ret, _ := PlayWorldReturnPossible(allWordleWords, guessAnswers)
+	cache := NewMatcherCache(DefaultMatcherCacheSize)
+	defer cache.Release()
+	ret, _ := PlayWorldReturnPossible(cache, allWordleWords, guessAnswers)
 	return ret
 }
 
-func NextGuess1(allWords, possibleAnswers []WordleWord) WordleWord {
-	_, wordsPossible := BestGuess1(allWords, possibleAnswers, possibleAnswers, 1, len(possibleAnswers)+1)
+func NextGuess1(cache *MatcherCache, allWords, possibleAnswers []WordleWord) WordleWord {
+	_, wordsPossible := BestGuess1(cache, allWords, possibleAnswers, possibleAnswers, 1, len(possibleAnswers)+1)
 	return wordsPossible[0]
 }
 
 func FirstGuess1(allWords []string) (float32, []WordleWord) {
 	wws := StringsToWordleWords(allWords)
-	score, ret := BestGuess1(wws, wws, wws, 1, len(allWords))
+	score, ret := BestGuess1(nil, wws, wws, wws, 1, len(allWords))
 	return float32(score), ret
 }
 
@@ -185,7 +188,7 @@ func FirstGuessProvideInitialGuesses1(initialGuesses_s, allWords_s []string) (fl
 	allWords := StringsToWordleWords(allWords_s)
 	initialGuesses := StringsToWordleWords(initialGuesses_s)
 	// score, ret := BestGuess1(allWords, allWords, initialGuesses, 1, len(allwords))
-	score, ret := BestGuess1(allWords, allWords, initialGuesses, 1, 10)
+	score, ret := BestGuess1(nil, allWords, allWords, initialGuesses, 1, 10)
 	return float32(score), ret
 }
 
@@ -206,31 +209,15 @@ var matching2 bool = true
 var Logging bool = false
 var BetterGuesses map[string]int = make(map[string]int)
 
-type gameCache struct {
-	gameId int
-	score  int
-	words  []WordleWord
-}
-
-var gameCacheMap map[int]gameCache = make(map[int]gameCache)
-
-func scoreForPossibleWords(gameId int) (int, []WordleWord, bool) {
-	if ret, ok := gameCacheMap[gameId]; ok {
-		return ret.score, ret.words, true
-	}
-	return 0, nil, false
-}
-func rememberScoreForPossibleWords(gameId int, score int, words []WordleWord) (int, []WordleWord) {
-	if _, ok := gameCacheMap[gameId]; ok {
-		panic("already have score for " + fmt.Sprintf("%d", gameId))
-	}
-	gameCacheMap[gameId] = gameCache{gameId, score, words}
-	return score, words
-}
-
 // find best next guess, return the low score and the slice of words that have that score
 // The score will be the average number of guesses it will take to solve if one the best guesses is used
-func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+// The matchers and scores of the word lists are kept in the cache, a nil cache is made for this search and released
+// when it returns
+func ScoreAlgorithmRecursive(cache *MatcherCache, allWords, possibleWords, _initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+	if cache == nil {
+		cache = NewMatcherCache(DefaultMatcherCacheSize)
+		defer cache.Release()
+	}
 	const INIFINITY_SCORE = 1000000
 	if len(possibleWords) == 0 {
 		panic("possibleWords is empty")
@@ -244,8 +231,8 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 	}
 
 	// Using the possible words the best guess is the matching one for one solution and 2 for the rest of the solutions.
-	game := NewWordleMatcher(possibleWords)
-	if retScore, retWordsWithScore, ok := scoreForPossibleWords(game.id); ok {
+	game := cache.Matcher(possibleWords)
+	if retScore, retWordsWithScore, ok := cache.score(game); ok {
 		return retScore, retWordsWithScore
 	}
 	possibleWordsSet := make(map[string]bool)
@@ -258,7 +245,7 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 	if true {
 		maxGuessCount := 300
 		flagGuessCountCutOff = maxGuessCount - 100
-		sortedScores := ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, _initialGuesses, depth, bestScoreSoFar)
+		sortedScores := ScoreAlgorithmTotalMatches1LevelAll(cache, allWords, possibleWords, _initialGuesses, depth, bestScoreSoFar)
 		for guessCount := 0; (sortedScores.Len() > 0) && (guessCount < maxGuessCount); guessCount++ {
 			item := heap.Pop(sortedScores).(Item)
 			guess := item.Value
@@ -314,7 +301,7 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 			if (len(matching) == 1) && (string(matching[0][:]) == string(guess[:])) {
 				guessInPossibleWordsRemaining = false // this is the correct guess
 			} else {
-				subscore, _ := ScoreAlgorithmRecursive(allWords, matching, matching, depth+1, bestScoreSoFar)
+				subscore, _ := ScoreAlgorithmRecursive(cache, allWords, matching, matching, depth+1, bestScoreSoFar)
 				guessSolutionScore += subscore
 			}
 			score = score + ((guessSolutionScore - score) / (count + 1)) // running average
@@ -349,7 +336,7 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 			bestGuess = append(bestGuess, guess)
 		}
 	}
-	return rememberScoreForPossibleWords(game.id, bestScore, bestGuess)
+	return cache.rememberScore(game, bestScore, bestGuess)
 }
 
 /*************
@@ -464,15 +451,19 @@ func ScoreAlgorithmRecursive_try1(allWords, possibleWords, _initialGuesses []Wor
 }
 ***************/
 
-func ScoreAlgorithmTotalMatches1Level(allWords, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
-	minHeap := ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, initialGuesses, depth, bestScoreSoFar)
+func ScoreAlgorithmTotalMatches1Level(cache *MatcherCache, allWords, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+	minHeap := ScoreAlgorithmTotalMatches1LevelAll(cache, allWords, possibleWords, initialGuesses, depth, bestScoreSoFar)
 	ret := heap.Pop(minHeap).(Item)
 	return ret.Score, []WordleWord{ret.Value}
 }
 
 // total number of words
 func GuessScore(guess WordleWord, possibleWords []WordleWord, allWords []WordleWord, depth int) int {
-	game := NewWordleMatcher(possibleWords)
+	return guessScore(NewWordleMatcher(possibleWords), guess, possibleWords)
+}
+
+// guessScore is GuessScore with the matcher of the possible words
+func guessScore(game *WordleMatcher, guess WordleWord, possibleWords []WordleWord) int {
 	score := 0
 	guessInPossibleWords := false
 	for _, solution := range possibleWords {
@@ -489,7 +480,12 @@ func GuessScore(guess WordleWord, possibleWords []WordleWord, allWords []WordleW
 }
 
 // try all the guesses and return a map of score to guess.
-func ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) *MinHeap[Item] {
+// The matcher of the possible words is kept in the cache, a nil cache is made for this search and released
+func ScoreAlgorithmTotalMatches1LevelAll(cache *MatcherCache, allWords, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) *MinHeap[Item] {
+	if cache == nil {
+		cache = NewMatcherCache(DefaultMatcherCacheSize)
+		defer cache.Release()
+	}
 	ret := NewMinHeapWordleWordPriority()
 	if len(possibleWords) == 0 {
 		panic("possibleWords is empty")
@@ -512,14 +508,15 @@ func ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, initialGuesses
 			orderedGuesses = append(orderedGuesses, guess)
 		}
 	}
+	game := cache.Matcher(possibleWords)
 	for _, guess := range orderedGuesses {
-		score := GuessScore(guess, possibleWords, allWords, depth)
+		score := guessScore(game, guess, possibleWords)
 		heap.Push(ret, Item{Value: guess, Score: score})
 	}
 	return ret
 }
 
-var BestGuess1 func([]WordleWord, []WordleWord, []WordleWord, int, int) (int, []WordleWord) = ScoreAlgorithmTotalMatches1Level
+var BestGuess1 func(*MatcherCache, []WordleWord, []WordleWord, []WordleWord, int, int) (int, []WordleWord) = ScoreAlgorithmTotalMatches1Level
 
 // Simulate a game of wordle.
 // words_s - dictionary of words
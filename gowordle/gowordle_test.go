@@ -7,9 +7,21 @@ import (
 	"testing"
 
 	"github.com/bits-and-blooms/bitset"
+	"github.com/powellquiring/wordle/wordle"
 	"github.com/stretchr/testify/assert"
 )
 
+// WordleDictionary is a copy of the wordle solutions in the order they were published, wordle.SortedWordleDictionary
+// sorts the original in place
+var WordleDictionary = append([]string{}, wordle.WordleDictionary...)
+
+// SortedWordleDictionary is a sorted copy of WordleDictionary
+func SortedWordleDictionary() []string {
+	ret := append([]string{}, WordleDictionary...)
+	sort.Strings(ret)
+	return ret
+}
+
 func TestMany(t *testing.T) {
 	set := bitset.New(5)
 	set.Set(0)
@@ -20,7 +32,7 @@ func TestMany(t *testing.T) {
 
 func TestBest(t *testing.T) {
 	words := StringsToWordleWords([]string{"aaaaa", "abbbb"})
-	score := NextGuess1(words, words)
+	score := NextGuess1(nil, words, words)
 	assert.NotZero(t, score)
 	print("testbest")
 }
@@ -47,6 +59,46 @@ func TestMatching2(t *testing.T) {
 	assert.Equal(matching, StringsToWordleWords([]string{"abbbb"}))
 }
 
+func TestMatcherCache(t *testing.T) {
+	assert := assert.New(t)
+	assert.Panics(func() { NewMatcherCache(0) })
+	ab := StringsToWordleWords([]string{"aaaaa", "abbbb"})
+	bc := StringsToWordleWords([]string{"bbbbb", "bcccc"})
+	cd := StringsToWordleWords([]string{"ccccc", "cdddd"})
+	cache := NewMatcherCache(2)
+	matcher := cache.Matcher(ab)
+	assert.Same(matcher, cache.Matcher(StringsToWordleWords([]string{"aaaaa", "abbbb"})))
+	assert.Equal(1, cache.HitCount)
+	assert.Equal(1, cache.MissCount)
+	cache.rememberScore(matcher, 150, ab[:1])
+	score, words, ok := cache.score(matcher)
+	assert.True(ok)
+	assert.Equal(150, score)
+	assert.Equal(ab[:1], words)
+
+	// the size limit evicts the least recently used matcher and its score, ab was used after bc
+	bcMatcher := cache.Matcher(bc)
+	cache.Matcher(ab)
+	cache.Matcher(cd)
+	assert.Equal(2, cache.Len())
+	assert.Same(matcher, cache.Matcher(ab))
+	assert.NotSame(bcMatcher, cache.Matcher(bc))
+	assert.Equal(2, cache.Len())
+	// cd was evicted by bc, ab was used after cd
+	_, _, ok = cache.score(matcher)
+	assert.True(ok)
+	cache.Matcher(cd)
+	_, _, ok = cache.score(matcher)
+	assert.False(ok, "ab was evicted by cd")
+
+	cache.Release()
+	assert.Equal(0, cache.Len())
+	_, _, ok = cache.score(matcher)
+	assert.False(ok)
+	assert.NotSame(matcher, cache.Matcher(ab))
+	assert.Equal(1, cache.Len())
+}
+
 func WordSort(ws []WordleWord) []string {
 	s := make([]string, len(ws))
 	for i, w := range ws {
@@ -116,7 +168,7 @@ func TestFirst20(t *testing.T) {
 func TestSimple(t *testing.T) {
 	possibleWords := StringsToWordleWords([]string{"clack", "clamp", "clank", "cloak", "local", "octal", "vocal"})
 	wordList := append(StringsToWordleWords([]string{"thank"}), possibleWords...)
-	ScoreAlgorithmTotalMatches1LevelAll(wordList, possibleWords, possibleWords, 1, 1000)
+	ScoreAlgorithmTotalMatches1LevelAll(nil, wordList, possibleWords, possibleWords, 1, 1000)
 }
 func TestFirst(t *testing.T) {
 	wordList := SortedWordleDictionary()[0:100]
@@ -138,8 +190,6 @@ func TestFirstWithInitialGuesses(t *testing.T) {
 	score, words := FirstGuessProvideInitialGuesses1(wordList, wordList)
 	println(score)
 	PrintWords(words)
-	println("hits:", HitCount)
-	println("miss:", MissCount)
 }
 
 /*
@@ -354,6 +404,10 @@ func BenchmarkFirst1(t *testing.B) {
 	// println("miss:", MissCount)
 }
 
+// hits and misses of the answer caches compared by the map benchmarks
+var HitCount int
+var MissCount int
+
 var HitmissMap map[string]*Answer = make(map[string]*Answer, 10000)
 
 func testMap(solution, guess WordleWord) *Answer {
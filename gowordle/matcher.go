@@ -1,6 +1,12 @@
 package gowordle
 
 import (
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
 	"github.com/bits-and-blooms/bitset"
 )
 
@@ -30,78 +36,108 @@ type WordleMatcher struct {
 	id      int
 }
 
-var cachedWordleMatchers map[string]*WordleMatcher = make(map[string]*WordleMatcher)
 var wordleMatcherID int = 0
 
-func findWordleMatcher1(words []WordleWord) (*WordleMatcher, bool) {
-	keyRune := make([]rune, len(words)*5)
-	for _, word := range words {
-		keyRune = append(keyRune, word[:]...)
-	}
-	key := string(keyRune)
-	if ret, ok := cachedWordleMatchers[key]; ok {
-		return ret, ok
+// DefaultMatcherCacheSize is the number of matchers kept by a MatcherCache made for a single search
+const DefaultMatcherCacheSize = 10_000
+
+// MatcherCache keeps the most recently used matchers for word lists, and the scores of the recursive search for the
+// word lists, up to a maximum number of matchers.  The least recently used matcher and its score are evicted when the
+// cache is full.  The caller controls the lifetime, Release it when done, for example after a game.
+type MatcherCache struct {
+	maxMatchers int
+	entries     map[[sha256.Size]byte]*list.Element // matcherKey of the word list to an element of lru
+	byID        map[int]*matcherEntry               // matcher id to entry
+	lru         *list.List                          // *matcherEntry, most recently used first
+	HitCount    int
+	MissCount   int
+}
+
+type matcherEntry struct {
+	key     [sha256.Size]byte
+	matcher *WordleMatcher
+	scored  bool // score and words have been remembered
+	score   int
+	words   []WordleWord
+}
+
+// NewMatcherCache makes an empty cache that holds at most maxMatchers matchers
+func NewMatcherCache(maxMatchers int) *MatcherCache {
+	if maxMatchers < 1 {
+		panic("bad maxMatchers")
 	}
-	ret := &WordleMatcher{}
-	wordleMatcherID++
-	ret.id = wordleMatcherID
-	ret.words = words
-	cachedWordleMatchers[key] = ret
-	return ret, false
+	ret := &MatcherCache{maxMatchers: maxMatchers}
+	ret.Release()
+	return ret
 }
 
-type WordleWordStruct struct {
-	word WordleWord
+// matcherKey is a hash of the words so the size of a key does not grow with the number of words
+func matcherKey(words []WordleWord) [sha256.Size]byte {
+	keyBytes := make([]byte, 0, len(words)*5)
+	for _, word := range words {
+		for _, letter := range word {
+			keyBytes = utf8.AppendRune(keyBytes, letter)
+		}
+	}
+	return sha256.Sum256(keyBytes)
 }
-type WordleMatcherAtDepth struct {
-	matcher *WordleMatcher
-	deeper  map[WordleWordStruct]*WordleMatcherAtDepth
+
+// Matcher returns the cached matcher for the words or makes and caches a new one
+func (c *MatcherCache) Matcher(words []WordleWord) *WordleMatcher {
+	key := matcherKey(words)
+	if element, ok := c.entries[key]; ok {
+		c.HitCount++
+		c.lru.MoveToFront(element)
+		return element.Value.(*matcherEntry).matcher
+	}
+	c.MissCount++
+	if c.lru.Len() >= c.maxMatchers {
+		oldest := c.lru.Remove(c.lru.Back()).(*matcherEntry)
+		delete(c.entries, oldest.key)
+		delete(c.byID, oldest.matcher.id)
+	}
+	entry := &matcherEntry{key: key, matcher: NewWordleMatcher(words)}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.byID[entry.matcher.id] = entry
+	return entry.matcher
 }
 
-var depthMatchers *WordleMatcherAtDepth
+// Len returns the number of cached matchers
+func (c *MatcherCache) Len() int {
+	return c.lru.Len()
+}
 
-var depthMatcherHitCount int
+// Release drops all of the cached matchers and scores, the cache can be used again
+func (c *MatcherCache) Release() {
+	c.entries = make(map[[sha256.Size]byte]*list.Element)
+	c.byID = make(map[int]*matcherEntry)
+	c.lru = list.New()
+}
 
-func init() {
-	depthMatchers = &WordleMatcherAtDepth{
-		deeper: make(map[WordleWordStruct]*WordleMatcherAtDepth),
+// score returns the remembered score of the matcher's words
+func (c *MatcherCache) score(matcher *WordleMatcher) (int, []WordleWord, bool) {
+	if entry, ok := c.byID[matcher.id]; ok && entry.scored {
+		return entry.score, entry.words, true
 	}
+	return 0, nil, false
 }
 
-func findWordleMatcher(words []WordleWord) (*WordleMatcher, bool) {
-	depth := depthMatchers
-	for _, word := range words {
-		if deeper, ok := depth.deeper[WordleWordStruct{word}]; !ok {
-			// map does not contain the word, so create and add
-			nextDeeper := &WordleMatcherAtDepth{
-				deeper: make(map[WordleWordStruct]*WordleMatcherAtDepth),
-			}
-			depth.deeper[WordleWordStruct{word}] = nextDeeper
-			depth = nextDeeper
-		} else {
-			depth = deeper
+// rememberScore remembers the score of the matcher's words if the matcher is still cached
+func (c *MatcherCache) rememberScore(matcher *WordleMatcher, score int, words []WordleWord) (int, []WordleWord) {
+	if entry, ok := c.byID[matcher.id]; ok {
+		if entry.scored {
+			panic("already have score for " + fmt.Sprintf("%d", matcher.id))
 		}
+		entry.scored, entry.score, entry.words = true, score, words
 	}
-	if depth.matcher == nil {
-		// store the new matcher
-		wordleMatcherID++
-		depth.matcher = &WordleMatcher{}
-		depth.matcher.id = wordleMatcherID
-		depth.matcher.words = words
-		return depth.matcher, false
-	} else {
-		depthMatcherHitCount++
-		return depth.matcher, true
-	}
+	return score, words
 }
 
 // take a slice of strings and make wordle words
 func NewWordleMatcher(words []WordleWord) *WordleMatcher {
 	// VerifyWordsAreSorted(words)
-	ret, ok := findWordleMatcher(words)
-	if ok {
-		return ret
-	}
+	wordleMatcherID++
+	ret := &WordleMatcher{id: wordleMatcherID, words: words}
 	ret.count = make(map[rune][]*bitset.BitSet, 26)
 	for w, word := range words {
 		word_letters := make(map[rune]int, 5)
@@ -132,6 +168,37 @@ func NewWordleMatcher(words []WordleWord) *WordleMatcher {
 	return ret
 }
 
+// Words returns the words of the matcher
+func (wd *WordleMatcher) Words() []WordleWord {
+	return wd.words
+}
+
+// Letters returns the letters that are in any of the words, sorted
+func (wd *WordleMatcher) Letters() []rune {
+	ret := make([]rune, 0, len(wd.count))
+	for letter := range wd.count {
+		ret = append(ret, letter)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// PositionCount returns the number of words with the letter at the position, the first position is 0
+func (wd *WordleMatcher) PositionCount(position int, letter rune) int {
+	if letters, ok := wd.letters[position][letter]; ok {
+		return int(letters.Count())
+	}
+	return 0
+}
+
+// LetterCount returns the number of words with at least n of the letter
+func (wd *WordleMatcher) LetterCount(letter rune, n int) int {
+	if n < 1 || n > len(wd.count[letter]) {
+		return 0
+	}
+	return int(wd.count[letter][n-1].Count())
+}
+
 type LetterCount struct {
 	letter rune
 	count  int
@@ -190,17 +257,7 @@ type Answer struct {
 	mustNot []LetterCount
 }
 
-var Hitmiss map[string]Answer = make(map[string]Answer, 10000)
-var HitCount int
-var MissCount int
-
 func WordleAnswer2(solution, guess WordleWord) Answer {
-	key := string(solution[:]) + string(guess[:])
-	if ret, ok := Hitmiss[key]; ok {
-		HitCount++
-		return ret
-	}
-
 	ret := Answer{
 		guess: guess,
 		// must:    []LetterCount{},
@@ -209,46 +266,55 @@ func WordleAnswer2(solution, guess WordleWord) Answer {
 		mustNot: make([]LetterCount, 0, 5),
 		Colors:  WordleWord([]rune{'r', 'r', 'r', 'r', 'r'}),
 	}
-	solutionNotGreenCount := [26]int{}
-	guessYellowGreenCount := [26]int{}
-	must := [26]bool{}
-	mustNot := [26]bool{}
+	// counts are indexed by the first position of the letter in the guess so any rune can be a letter
+	letterIndex := func(letter rune) int {
+		for i, guessLetter := range guess {
+			if guessLetter == letter {
+				return i
+			}
+		}
+		return -1
+	}
+	solutionNotGreenCount := [5]int{}
+	guessYellowGreenCount := [5]int{}
+	must := [5]bool{}
+	mustNot := [5]bool{}
 	for i, solutionLetter := range solution {
 		guessLetter := guess[i]
 		if solutionLetter == guessLetter {
 			ret.Colors[i] = 'g'
-			guessYellowGreenCount[guessLetter-'a'] = guessYellowGreenCount[guessLetter-'a'] + 1
-		} else {
-			// answer[i] = 'r'
-			solutionNotGreenCount[solutionLetter-'a'] = solutionNotGreenCount[solutionLetter-'a'] + 1
+			guessYellowGreenCount[letterIndex(guessLetter)]++
+		} else if index := letterIndex(solutionLetter); index >= 0 {
+			// answer[i] = 'r', only the letters in the guess can turn yellow
+			solutionNotGreenCount[index]++
 		}
 	}
 	// turn the red to yellow if in the word but not green
 	for i, guessLetter := range guess {
+		index := letterIndex(guessLetter)
 		if ret.Colors[i] == 'r' {
-			if solutionNotGreenCount[guessLetter-'a'] > 0 {
+			if solutionNotGreenCount[index] > 0 {
 				ret.Colors[i] = 'y'
-				solutionNotGreenCount[guessLetter-'a'] = solutionNotGreenCount[guessLetter-'a'] - 1
-				guessYellowGreenCount[guessLetter-'a'] = guessYellowGreenCount[guessLetter-'a'] + 1
+				solutionNotGreenCount[index]--
+				guessYellowGreenCount[index]++
 			}
 		}
 	}
 	for i, guessLetter := range guess {
+		index := letterIndex(guessLetter)
 		if ret.Colors[i] == 'r' {
-			if !mustNot[guessLetter-'a'] {
-				ret.mustNot = append(ret.mustNot, LetterCount{guessLetter, guessYellowGreenCount[guessLetter-'a']})
-				mustNot[guessLetter-'a'] = true
+			if !mustNot[index] {
+				ret.mustNot = append(ret.mustNot, LetterCount{guessLetter, guessYellowGreenCount[index]})
+				mustNot[index] = true
 			}
 		} else if ret.Colors[i] == 'y' {
-			if !must[guessLetter-'a'] {
+			if !must[index] {
 				// add one for each red letter
-				ret.must = append(ret.must, LetterCount{guessLetter, guessYellowGreenCount[guessLetter-'a'] - 1})
-				must[guessLetter-'a'] = true
+				ret.must = append(ret.must, LetterCount{guessLetter, guessYellowGreenCount[index] - 1})
+				must[index] = true
 			}
 		}
 	}
-	MissCount++
-	Hitmiss[key] = ret
 	return ret
 }
 
@@ -298,7 +364,10 @@ func (wd *WordleMatcher) matchingWorker(guess, answer WordleWord, must, must_not
 	// if there are greens then the starting point only contains words with matching letter
 	for i, color := range answer {
 		if color == 'g' {
-			set := wd.letters[i][guess[i]]
+			set, ok := wd.letters[i][guess[i]]
+			if !ok {
+				return []WordleWord{} // no word has the green letter in this position
+			}
 			ret.InPlaceIntersection(set)
 		}
 	}
@@ -308,12 +377,11 @@ func (wd *WordleMatcher) matchingWorker(guess, answer WordleWord, must, must_not
 	for _, letterCount := range must {
 		yellow := letterCount.letter
 		count := letterCount.count
-		if counts, ok := wd.count[yellow]; ok {
-			if len(counts) > count {
-				set := counts[count]
-				ret.InPlaceIntersection(set)
-			}
+		counts, ok := wd.count[yellow]
+		if !ok || len(counts) <= count {
+			return []WordleWord{} // no word has enough of the yellow letter
 		}
+		ret.InPlaceIntersection(counts[count])
 	}
 
 	// red letters removes words that do not contain the required count of matching letters
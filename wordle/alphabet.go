@@ -0,0 +1,88 @@
+package wordle
+
+import (
+	"sort"
+	"strings"
+)
+
+// Alphabet is the set of letters of the words in a dictionary.  Accented letters that are not part of the alphabet
+// can be folded into a letter that is, so a word typed as "pájaro" is the word "pajaro".
+type Alphabet struct {
+	Name    string
+	letters map[rune]bool
+	fold    map[rune]rune // accented letter to the letter of the alphabet
+}
+
+const englishLetters = "abcdefghijklmnopqrstuvwxyz"
+
+// NewAlphabet returns the alphabet of the letters, fold pairs an accented letter with the letter it is folded into
+func NewAlphabet(name string, letters string, fold map[rune]rune) *Alphabet {
+	ret := &Alphabet{Name: name, letters: make(map[rune]bool), fold: fold}
+	for _, letter := range letters {
+		ret.letters[letter] = true
+	}
+	return ret
+}
+
+var English = NewAlphabet("english", englishLetters, nil)
+
+var alphabets = []*Alphabet{
+	English,
+	NewAlphabet("spanish", englishLetters+"ñ", map[rune]rune{
+		'á': 'a', 'é': 'e', 'í': 'i', 'ó': 'o', 'ú': 'u', 'ü': 'u',
+	}),
+	NewAlphabet("german", englishLetters+"äöüß", nil),
+	NewAlphabet("portuguese", englishLetters, map[rune]rune{
+		'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'é': 'e', 'ê': 'e', 'í': 'i',
+		'ó': 'o', 'ô': 'o', 'õ': 'o', 'ú': 'u', 'ü': 'u', 'ç': 'c',
+	}),
+}
+
+// AlphabetByName returns one of the alphabets listed by AlphabetNames
+func AlphabetByName(name string) (*Alphabet, bool) {
+	for _, alphabet := range alphabets {
+		if alphabet.Name == name {
+			return alphabet, true
+		}
+	}
+	return nil, false
+}
+
+// AlphabetNames is the list of the names accepted by AlphabetByName
+func AlphabetNames() string {
+	names := []string{}
+	for _, alphabet := range alphabets {
+		names = append(names, alphabet.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Letters returns the letters of the alphabet, sorted
+func (a *Alphabet) Letters() []rune {
+	ret := make([]rune, 0, len(a.letters))
+	for letter := range a.letters {
+		ret = append(ret, letter)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// Contains is true if the letter is in the alphabet
+func (a *Alphabet) Contains(letter rune) bool {
+	return a.letters[letter]
+}
+
+// Normalize returns the lower case word without surrounding white space and with the accented letters folded
+func (a *Alphabet) Normalize(word string) string {
+	return strings.Map(func(letter rune) rune {
+		if folded, ok := a.fold[letter]; ok {
+			return folded
+		}
+		return letter
+	}, strings.ToLower(strings.TrimSpace(word)))
+}
+
+// Valid is true if the word has 5 letters of the alphabet
+func (a *Alphabet) Valid(word string) bool {
+	return a.wordProblem(word) == ""
+}
@@ -0,0 +1,225 @@
+package wordle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/powellquiring/wordle/bitset"
+)
+
+// Constraint is what the guess/answer pairs tell about the solution: the known green letters, the letters that are
+// not in a position and the minimum and maximum count of letters.  A minimum equal to the maximum is an exact count.
+// The counts follow MakeLetterMatch in gowordle, a yellow or green tile is one more of the letter and a red tile
+// means there are no more of the letter than the yellow and green tiles.
+type Constraint struct {
+	Greens        [5]rune          // letter in each position, 0 if not known
+	NotAt         [5]map[rune]bool // letters that are not in each position
+	MinCount      map[rune]int     // the solution has at least this many of the letter
+	MaxCount      map[rune]int     // the solution has at most this many of the letter, missing if not known
+	contradiction bool             // two of the pairs have different green letters in the same position
+}
+
+// NewConstraint accumulates the guess/answer pairs, the answers can have ? for tiles with an unknown colour.  The
+// second return value is false if one of the pairs is not valid.
+func NewConstraint(guessAnswers []GuessAnswer) (Constraint, bool) {
+	ret := Constraint{}
+	for _, guessAnswer := range guessAnswers {
+		if !ret.Add(guessAnswer) {
+			return Constraint{}, false
+		}
+	}
+	return ret, true
+}
+
+// Add accumulates one more guess/answer pair, false if the pair is not valid
+func (c *Constraint) Add(guessAnswer GuessAnswer) bool {
+	guess := []rune(guessAnswer.Guess)
+	partialAnswer, ok := StringToPartialAnswer(guessAnswer.Answer)
+	if !ok || len(guess) != 5 || len([]rune(guessAnswer.Answer)) != 5 {
+		return false
+	}
+	// the colours as r, y, g and ?, the answer can also be the emoji tiles of a share grid
+	answer := []rune(partialAnswer.String())
+	other := Constraint{}
+	for tile, letter := range guess {
+		switch answer[tile] {
+		case 'g':
+			other.Greens[tile] = letter
+		case 'y', 'r':
+			other.notAt(tile, letter)
+		}
+		yellowGreen := yellowGreenCount(guess, answer, letter)
+		if yellowGreen > 0 {
+			other.setMin(letter, yellowGreen)
+		}
+		if answer[tile] == 'r' {
+			other.setMax(letter, yellowGreen+colorCount(guess, answer, letter, '?'))
+		}
+	}
+	c.Merge(other)
+	return true
+}
+
+// Merge adds everything known by the other constraint
+func (c *Constraint) Merge(other Constraint) {
+	for position := range 5 {
+		if green := other.Greens[position]; green != 0 {
+			if c.Greens[position] != 0 && c.Greens[position] != green {
+				c.contradiction = true
+			}
+			c.Greens[position] = green
+		}
+		for letter := range other.NotAt[position] {
+			c.notAt(position, letter)
+		}
+	}
+	for letter, count := range other.MinCount {
+		c.setMin(letter, count)
+	}
+	for letter, count := range other.MaxCount {
+		c.setMax(letter, count)
+	}
+	c.contradiction = c.contradiction || other.contradiction
+}
+
+func (c *Constraint) notAt(position int, letter rune) {
+	if c.NotAt[position] == nil {
+		c.NotAt[position] = map[rune]bool{}
+	}
+	c.NotAt[position][letter] = true
+}
+
+// setMin raises the minimum count of the letter
+func (c *Constraint) setMin(letter rune, count int) {
+	if c.MinCount == nil {
+		c.MinCount = map[rune]int{}
+	}
+	c.MinCount[letter] = max(c.MinCount[letter], count)
+}
+
+// setMax lowers the maximum count of the letter
+func (c *Constraint) setMax(letter rune, count int) {
+	if c.MaxCount == nil {
+		c.MaxCount = map[rune]int{}
+	}
+	if previous, ok := c.MaxCount[letter]; ok {
+		count = min(previous, count)
+	}
+	c.MaxCount[letter] = count
+}
+
+// countWords are the counts written out in String
+var countWords = []string{"no", "one", "two", "three", "four", "five"}
+
+func countWord(count int) string {
+	if count < len(countWords) {
+		return countWords[count]
+	}
+	return fmt.Sprint(count)
+}
+
+// String is the human readable form, like "a in position 1, e not in position 2, at least two s, no t"
+func (c Constraint) String() string {
+	parts := []string{}
+	for position, green := range c.Greens {
+		if green != 0 {
+			parts = append(parts, fmt.Sprintf("%c in position %d", green, position+1))
+		}
+	}
+	for position, letters := range c.NotAt {
+		for _, letter := range sortedLetters(letters) {
+			if c.MaxCount[letter] == 0 && hasCount(c.MaxCount, letter) {
+				continue // not in the word at all
+			}
+			parts = append(parts, fmt.Sprintf("%c not in position %d", letter, position+1))
+		}
+	}
+	letters := map[rune]bool{}
+	for letter := range c.MinCount {
+		letters[letter] = true
+	}
+	for letter := range c.MaxCount {
+		letters[letter] = true
+	}
+	for _, letter := range sortedLetters(letters) {
+		minCount := c.MinCount[letter]
+		maxCount, maxKnown := c.MaxCount[letter]
+		switch {
+		case maxKnown && maxCount == minCount:
+			if minCount == 0 {
+				parts = append(parts, fmt.Sprintf("no %c", letter))
+			} else {
+				parts = append(parts, fmt.Sprintf("exactly %s %c", countWord(minCount), letter))
+			}
+		case maxKnown:
+			if minCount > 0 {
+				parts = append(parts, fmt.Sprintf("at least %s %c", countWord(minCount), letter))
+			}
+			parts = append(parts, fmt.Sprintf("at most %s %c", countWord(maxCount), letter))
+		case minCount > 0:
+			parts = append(parts, fmt.Sprintf("at least %s %c", countWord(minCount), letter))
+		}
+	}
+	if c.contradiction {
+		parts = append(parts, "contradicting greens")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func hasCount(counts map[rune]int, letter rune) bool {
+	_, ok := counts[letter]
+	return ok
+}
+
+func sortedLetters(letters map[rune]bool) []rune {
+	ret := make([]rune, 0, len(letters))
+	for letter := range letters {
+		ret = append(ret, letter)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// Matching returns the words of the dictionary that satisfy the constraint in a single pass over the letter
+// bitsets of the dictionary's matcher
+func (c Constraint) Matching(d *Dictionary) *WordList {
+	m := d.matcher
+	ret := d.WordlistAll()
+	result := (*bitset.BitSet)(ret)
+	if c.contradiction {
+		return d.WordlistEmpty()
+	}
+	for position, green := range c.Greens {
+		if green == 0 {
+			continue
+		}
+		letter, ok := m.letterIndex[green]
+		if !ok {
+			return d.WordlistEmpty()
+		}
+		result.IntersectionInPlace((*bitset.BitSet)(&m.position[position][letter]), result)
+	}
+	for position, letters := range c.NotAt {
+		for notAt := range letters {
+			if letter, ok := m.letterIndex[notAt]; ok {
+				result.DifferenceInPlace((*bitset.BitSet)(&m.position[position][letter]), result)
+			}
+		}
+	}
+	for minLetter, count := range c.MinCount {
+		letter, ok := m.letterIndex[minLetter]
+		if count > 5 || (!ok && count > 0) {
+			return d.WordlistEmpty()
+		}
+		if count > 0 {
+			result.IntersectionInPlace((*bitset.BitSet)(&m.count[letter][count-1]), result)
+		}
+	}
+	for maxLetter, count := range c.MaxCount {
+		if letter, ok := m.letterIndex[maxLetter]; ok && count < 5 {
+			result.DifferenceInPlace((*bitset.BitSet)(&m.count[letter][count]), result)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,148 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Contradiction explains why a list of guess/answer pairs leaves no possible words.
+type Contradiction struct {
+	Pair        int          // index of the first guess/answer pair that left no possible words
+	GuessAnswer GuessAnswer  // the guess/answer pair at Pair
+	Remaining   int          // number of possible words before the pair was applied
+	Tile        int          // index of the tile whose constraint removed the last possible words
+	Constraint  string       // the constraint of the tile, like "a is not in the word"
+	Corrections []Correction // single tile colour changes that leave at least one possible word
+}
+
+// Correction is a single tile colour change to one of the guess/answer pairs that makes all of the pairs consistent
+type Correction struct {
+	Pair          int         // index of the corrected guess/answer pair
+	Tile          int         // index of the tile with the changed colour
+	GuessAnswer   GuessAnswer // the corrected pair
+	PossibleWords int         // number of possible words after the correction
+}
+
+// FindContradiction returns the contradiction if the guess/answer pairs leave no possible words.  The second return
+// value is false if there is at least one possible word.
+func (d *Dictionary) FindContradiction(guessAnswers []GuessAnswer) (Contradiction, bool) {
+	if d.PossibleWords(guessAnswers).Len() > 0 {
+		return Contradiction{}, false
+	}
+	ret := Contradiction{}
+	previous := d.WordlistAll()
+	for pair := range guessAnswers {
+		possible := d.PossibleWords(guessAnswers[:pair+1])
+		if possible.Len() == 0 {
+			ret.Pair = pair
+			ret.GuessAnswer = guessAnswers[pair]
+			ret.Remaining = previous.Len()
+			break
+		}
+		previous = possible
+	}
+
+	// apply the constraint of each tile in turn to find the one that removes the last possible words
+	words := d.WordlistStrings(previous)
+	guess := []rune(ret.GuessAnswer.Guess)
+	answer := []rune(ret.GuessAnswer.Answer)
+	for tile := range guess {
+		remaining := []string{}
+		for _, word := range words {
+			if tileMatches(guess, answer, tile, []rune(word)) {
+				remaining = append(remaining, word)
+			}
+		}
+		if len(remaining) == 0 {
+			ret.Tile = tile
+			ret.Constraint = tileConstraint(guess, answer, tile)
+			break
+		}
+		words = remaining
+	}
+	ret.Corrections = d.singleTileCorrections(guessAnswers)
+	return ret, true
+}
+
+// singleTileCorrections tries every other colour for every tile of every guess/answer pair
+func (d *Dictionary) singleTileCorrections(guessAnswers []GuessAnswer) []Correction {
+	ret := []Correction{}
+	for pair, guessAnswer := range guessAnswers {
+		for tile := range []rune(guessAnswer.Answer) {
+			for _, color := range "ryg" {
+				answer := []rune(guessAnswer.Answer)
+				if answer[tile] == color || answer[tile] == '?' {
+					continue // a known colour for an unknown tile can only remove words
+				}
+				answer[tile] = color
+				corrected := GuessAnswer{Guess: guessAnswer.Guess, Answer: string(answer)}
+				tryGuessAnswers := append([]GuessAnswer{}, guessAnswers...)
+				tryGuessAnswers[pair] = corrected
+				if possible := d.PossibleWords(tryGuessAnswers).Len(); possible > 0 {
+					ret = append(ret, Correction{Pair: pair, Tile: tile, GuessAnswer: corrected, PossibleWords: possible})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// yellowGreenCount is the number of tiles in the guess with the letter that are yellow or green
+func yellowGreenCount(guess, answer []rune, letter rune) int {
+	return colorCount(guess, answer, letter, 'y') + colorCount(guess, answer, letter, 'g')
+}
+
+// colorCount is the number of tiles in the guess with the letter and the colour
+func colorCount(guess, answer []rune, letter rune, color rune) int {
+	ret := 0
+	for i, guessLetter := range guess {
+		if guessLetter == letter && answer[i] == color {
+			ret++
+		}
+	}
+	return ret
+}
+
+// tileMatches returns true if the word is consistent with the colour of a single tile
+func tileMatches(guess, answer []rune, tile int, word []rune) bool {
+	letter := guess[tile]
+	if answer[tile] == '?' {
+		return true
+	}
+	if answer[tile] == 'g' {
+		return word[tile] == letter
+	}
+	if word[tile] == letter {
+		return false // would have been green
+	}
+	count := strings.Count(string(word), string(letter))
+	if answer[tile] == 'y' {
+		return count >= yellowGreenCount(guess, answer, letter)
+	}
+	// tiles of the letter with an unknown colour may or may not be in the word
+	yellowGreen := yellowGreenCount(guess, answer, letter)
+	return count >= yellowGreen && count <= yellowGreen+colorCount(guess, answer, letter, '?')
+}
+
+// tileConstraint is the human readable form of the constraint checked by tileMatches
+func tileConstraint(guess, answer []rune, tile int) string {
+	letter := guess[tile]
+	position := tile + 1
+	count := yellowGreenCount(guess, answer, letter)
+	switch answer[tile] {
+	case '?':
+		return fmt.Sprintf("the colour of %c in position %d is unknown", letter, position)
+	case 'g':
+		return fmt.Sprintf("%c is in position %d", letter, position)
+	case 'y':
+		if count > 1 {
+			return fmt.Sprintf("%c is not in position %d and there are at least %d %c", letter, position, count, letter)
+		}
+		return fmt.Sprintf("%c is in the word but not in position %d", letter, position)
+	default:
+		if count == 0 {
+			return fmt.Sprintf("%c is not in the word", letter)
+		}
+		return fmt.Sprintf("%c is not in position %d and there are exactly %d %c", letter, position, count, letter)
+	}
+}
@@ -0,0 +1,54 @@
+package wordle
+
+import (
+	"math"
+	"sort"
+)
+
+// Deduction is a solution that can produce every row of the share grids
+type Deduction struct {
+	Solution      WordleWord
+	LogLikelihood float64 // sum over the rows of the log of the fraction of the guesses that produce the row
+}
+
+// answerCounts is the number of guesses that produce each answer for the solution
+func (d *Dictionary) answerCounts(solution WordleWord) *[1 << 10]int {
+	ret := &[1 << 10]int{}
+	solutionRunes := []rune(d.String(solution))
+	for _, guess := range d.words {
+		ret[answerColors(solutionRunes, []rune(guess))]++
+	}
+	return ret
+}
+
+// Deduce returns the solutions where every row of every grid can be produced by some guess, the most likely first.
+// A row is more likely for a solution when more of the guesses produce it, as if each guess was picked at random.
+func (d *Dictionary) Deduce(grids [][]Answer) []Deduction {
+	ret := []Deduction{}
+	guesses := float64(d.Len())
+	for solution := range WordleWord(d.Len()) {
+		counts := d.answerCounts(solution)
+		deduction := Deduction{Solution: solution}
+		possible := true
+		for _, grid := range grids {
+			for _, row := range grid {
+				count := counts[row]
+				if count == 0 {
+					possible = false
+					break
+				}
+				deduction.LogLikelihood += math.Log(float64(count) / guesses)
+			}
+			if !possible {
+				break
+			}
+		}
+		if possible {
+			ret = append(ret, deduction)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].LogLikelihood > ret[j].LogLikelihood
+	})
+	return ret
+}
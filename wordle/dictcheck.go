@@ -0,0 +1,131 @@
+package wordle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordProblem is a word in a word list that can not be used as is
+type WordProblem struct {
+	Line    int // line number of the word, the first line is 1
+	Word    string
+	Problem string
+}
+
+func (p WordProblem) String() string {
+	return fmt.Sprintf("line %d %q: %s", p.Line, p.Word, p.Problem)
+}
+
+// wordProblem describes what is wrong with a single word, the empty string if nothing
+func (a *Alphabet) wordProblem(word string) string {
+	notInAlphabet := func(r rune) bool { return !a.Contains(r) }
+	switch {
+	case strings.TrimSpace(word) == "":
+		return "blank line"
+	case word != strings.TrimSpace(word):
+		return "white space around the word"
+	case strings.IndexFunc(word, unicode.IsUpper) >= 0:
+		return "upper case letters"
+	case strings.IndexFunc(word, notInAlphabet) >= 0:
+		letters := strings.Map(func(r rune) rune {
+			if notInAlphabet(r) {
+				return r
+			}
+			return -1
+		}, word)
+		if strings.IndexFunc(a.Normalize(letters), notInAlphabet) < 0 {
+			return "accented letters that fold into the " + a.Name + " alphabet: " + letters
+		}
+		return "letters not in the " + a.Name + " alphabet: " + letters
+	case utf8.RuneCountInString(word) != 5:
+		return fmt.Sprintf("%d letters, not 5", utf8.RuneCountInString(word))
+	}
+	return ""
+}
+
+// CheckWords checks a list of english words, see Alphabet.CheckWords
+func CheckWords(lines []string) []WordProblem {
+	return English.CheckWords(lines)
+}
+
+// CheckWords returns the problems with a word list, one word per line: words that are not 5 lower case letters of
+// the alphabet, duplicates and words that are out of order.  A list with no problems can be used for a Dictionary.
+func (a *Alphabet) CheckWords(lines []string) []WordProblem {
+	ret := []WordProblem{}
+	firstLine := make(map[string]int)
+	previous := ""
+	for i, word := range lines {
+		line := i + 1
+		if problem := a.wordProblem(word); problem != "" {
+			ret = append(ret, WordProblem{Line: line, Word: word, Problem: problem})
+		}
+		normalized := a.Normalize(word)
+		if normalized == "" {
+			continue
+		}
+		if first, ok := firstLine[normalized]; ok {
+			ret = append(ret, WordProblem{Line: line, Word: word, Problem: fmt.Sprintf("duplicate of line %d", first)})
+			continue
+		}
+		firstLine[normalized] = line
+		if normalized < previous {
+			ret = append(ret, WordProblem{Line: line, Word: word, Problem: fmt.Sprintf("not sorted, comes before %q", previous)})
+		}
+		previous = normalized
+	}
+	return ret
+}
+
+// NormalizeWords normalizes a list of english words, see Alphabet.NormalizeWords
+func NormalizeWords(lines []string) (words []string, dropped []string) {
+	return English.NormalizeWords(lines)
+}
+
+// NormalizeWords returns the sorted list of unique words after Normalize.  The words that are still not 5 letters
+// of the alphabet are returned as dropped.
+func (a *Alphabet) NormalizeWords(lines []string) (words []string, dropped []string) {
+	unique := make(map[string]bool)
+	for _, word := range lines {
+		normalized := a.Normalize(word)
+		if normalized == "" {
+			continue
+		}
+		if a.wordProblem(normalized) != "" {
+			dropped = append(dropped, word)
+			continue
+		}
+		unique[normalized] = true
+	}
+	words = make([]string, 0, len(unique))
+	for word := range unique {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words, dropped
+}
+
+// DiffWords returns the words that are only in the new list and the words that are only in the old list, sorted
+func DiffWords(oldWords, newWords []string) (added []string, removed []string) {
+	inOld := make(map[string]bool, len(oldWords))
+	for _, word := range oldWords {
+		inOld[word] = true
+	}
+	inNew := make(map[string]bool, len(newWords))
+	for _, word := range newWords {
+		inNew[word] = true
+		if !inOld[word] {
+			added = append(added, word)
+		}
+	}
+	for _, word := range oldWords {
+		if !inNew[word] {
+			removed = append(removed, word)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
@@ -0,0 +1,200 @@
+package wordle
+
+import (
+	"math"
+	"sort"
+)
+
+// GuessReport describes how well a guess splits the possible words
+type GuessReport struct {
+	Guess         WordleWord
+	Expected      float64 // expected number of guesses to solve, including this guess, when the solver plays the rest, see ExplainGuess
+	WorstCase     int     // most guesses needed to solve, including this guess, when the solver plays the rest
+	Buckets       int     // number of distinct answers
+	Entropy       float64 // bits of information in the answer
+	LargestBucket int     // most possible words that share an answer
+	Candidate     bool    // the guess is one of the possible words
+}
+
+// Partition returns the possible words grouped by the answer to the guess, ordered by answer
+func (d *Dictionary) Partition(possibleWords *WordList, guess WordleWord) []FullAnswer {
+	var fullanswerPossibleWords WordList
+	buckets := make(map[Answer]*WordList)
+	for _, solution := range possibleWords.Range {
+		fullAnswer := d.GetFullAnswer(possibleWords, solution, guess, &fullanswerPossibleWords)
+		if _, ok := buckets[fullAnswer.AnswerColor]; !ok {
+			matching := *fullAnswer.AnswerMatching
+			buckets[fullAnswer.AnswerColor] = &matching
+		}
+	}
+	ret := make([]FullAnswer, 0, len(buckets))
+	for answer, matching := range buckets {
+		ret = append(ret, FullAnswer{AnswerColor: answer, AnswerMatching: matching})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].AnswerColor < ret[j].AnswerColor
+	})
+	return ret
+}
+
+// AllGreenAnswer is the answer when the guess is the solution
+var AllGreenAnswer, _ = StringToAnswer("ggggg")
+
+// RankedGuesses scores every word in the dictionary like SortedGuesses, the total number of possible words that remain
+// after the guess summed over all the possible solutions, without stopping early for a perfect guess.  Lower scores are
+// better and possible words come first when scores are equal.
+func (d *Dictionary) RankedGuesses(possibleWords *WordList) []WordScore {
+	ret := make([]WordScore, 0, d.Len())
+	for guess := range d.Len() {
+		score := 0
+		for _, solution := range possibleWords.Range {
+			score += d.GetFullAnswerLength(possibleWords, solution, WordleWord(guess))
+		}
+		ret = append(ret, WordScore{Value: WordleWord(guess), Score: uint16(min(score, math.MaxUint16))})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score < ret[j].Score
+		}
+		return possibleWords.Contains(ret[i].Value) && !possibleWords.Contains(ret[j].Value)
+	})
+	return ret
+}
+
+// wordListCacheSize is the most lists of possible words remembered by each of the caches of a Dictionary
+const wordListCacheSize = 50_000
+
+// wordListCache remembers a value for each list of possible words.  It is emptied when it is full so a long running
+// process, like serve, does not grow without bound.
+type wordListCache[V any] struct {
+	maxEntries int
+	values     map[WordList]V
+}
+
+func newWordListCache[V any](maxEntries int) *wordListCache[V] {
+	return &wordListCache[V]{maxEntries: maxEntries, values: make(map[WordList]V)}
+}
+
+func (c *wordListCache[V]) get(possibleWords *WordList) (V, bool) {
+	ret, ok := c.values[*possibleWords]
+	return ret, ok
+}
+
+func (c *wordListCache[V]) set(possibleWords *WordList, value V) {
+	if len(c.values) >= c.maxEntries {
+		c.values = make(map[WordList]V)
+	}
+	c.values[*possibleWords] = value
+}
+
+// ExplainGuess reports the exact expected and worst case number of guesses when the guess is played next and the
+// solver plays the rest of the game, see SolverExpected.  The expected number and the entropy are weighted by how
+// likely each solution is.
+func (d *Dictionary) ExplainGuess(possibleWords *WordList, guess WordleWord) GuessReport {
+	ret := GuessReport{Guess: guess, Candidate: possibleWords.Contains(guess)}
+	possibleWeight := d.WordlistWeight(possibleWords)
+	buckets := d.Partition(possibleWords, guess)
+	for _, bucket := range buckets {
+		bucketWeight := d.WordlistWeight(bucket.AnswerMatching)
+		ret.Buckets++
+		ret.LargestBucket = max(ret.LargestBucket, bucket.AnswerMatching.Len())
+		p := float64(bucketWeight) / float64(possibleWeight)
+		ret.Entropy -= p * math.Log2(p)
+		ret.WorstCase = max(ret.WorstCase, d.bucketWorstCase(bucket))
+	}
+	ret.Expected = d.bucketsExpected(possibleWords, buckets)
+	return ret
+}
+
+// ExpectedGuesses is the Expected of ExplainGuess without the rest of the report, the worst case is not searched
+func (d *Dictionary) ExpectedGuesses(possibleWords *WordList, guess WordleWord) float64 {
+	return d.bucketsExpected(possibleWords, d.Partition(possibleWords, guess))
+}
+
+// bucketsExpected is the average over the possible words, weighted by how likely each solution is, of the guesses
+// needed after the partition, a sum without rounding
+func (d *Dictionary) bucketsExpected(possibleWords *WordList, buckets []FullAnswer) float64 {
+	total := 0.0
+	for _, bucket := range buckets {
+		total += float64(d.WordlistWeight(bucket.AnswerMatching)) * d.bucketExpected(bucket)
+	}
+	return total / float64(d.WordlistWeight(possibleWords))
+}
+
+// bucketExpected is the exact expected number of guesses, including the guess that produced the bucket, when the
+// solver plays the rest
+func (d *Dictionary) bucketExpected(bucket FullAnswer) float64 {
+	if bucket.AnswerColor == AllGreenAnswer {
+		return 1
+	}
+	return 1 + d.SolverExpected(bucket.AnswerMatching)
+}
+
+// SolverExpected is the exact expected number of guesses the solver needs to solve the possible words, it plays the
+// guess of NextGuess at every step like sim.  The NextGuessSearch score is the search's own estimate, a running
+// average of integers, and can differ.  Remembered for each list of possible words.
+func (d *Dictionary) SolverExpected(possibleWords *WordList) float64 {
+	if ret, ok := d.expectedCache.get(possibleWords); ok {
+		return ret
+	}
+	ret := d.ExpectedGuesses(possibleWords, d.NextGuess(possibleWords))
+	d.expectedCache.set(possibleWords, ret)
+	return ret
+}
+
+// guessWorstCase is the most guesses needed to solve, including the guess, when the solver plays the rest
+func (d *Dictionary) guessWorstCase(possibleWords *WordList, guess WordleWord) int {
+	ret := 0
+	for _, bucket := range d.Partition(possibleWords, guess) {
+		ret = max(ret, d.bucketWorstCase(bucket))
+	}
+	return ret
+}
+
+// bucketWorstCase is the most guesses, including the guess that produced the bucket, when the solver plays the rest
+func (d *Dictionary) bucketWorstCase(bucket FullAnswer) int {
+	if bucket.AnswerColor == AllGreenAnswer {
+		return 1
+	}
+	return 1 + d.WorstCase(bucket.AnswerMatching)
+}
+
+// WorstCase returns the most guesses the solver needs to solve any of the possible words, remembered for each list
+// of possible words
+func (d *Dictionary) WorstCase(possibleWords *WordList) int {
+	if ret, ok := d.worstCaseCache.get(possibleWords); ok {
+		return ret
+	}
+	ret := d.guessWorstCase(possibleWords, d.NextGuess(possibleWords))
+	d.worstCaseCache.set(possibleWords, ret)
+	return ret
+}
+
+// ExplainGuesses returns reports for the top guesses ordered by expected number of guesses then worst case.  The
+// guesses explained are the best of RankedGuesses along with the guess chosen by NextGuessSearch.
+func (d *Dictionary) ExplainGuesses(possibleWords *WordList, top int) []GuessReport {
+	_, best := d.NextGuessSearch(possibleWords, 0)
+	guesses := []WordleWord{best}
+	for _, wordScore := range d.RankedGuesses(possibleWords) {
+		if len(guesses) >= 2*top {
+			break
+		}
+		if wordScore.Value != best {
+			guesses = append(guesses, wordScore.Value)
+		}
+	}
+	ret := make([]GuessReport, 0, len(guesses))
+	for _, guess := range guesses {
+		ret = append(ret, d.ExplainGuess(possibleWords, guess))
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Expected != ret[j].Expected {
+			return ret[i].Expected < ret[j].Expected
+		}
+		return ret[i].WorstCase < ret[j].WorstCase
+	})
+	if len(ret) > top {
+		ret = ret[:top]
+	}
+	return ret
+}
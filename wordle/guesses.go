@@ -5,10 +5,10 @@ import (
 	"container/list"
 	"fmt"
 	"sort"
+	"strings"
 
 	//	"github.com/bits-and-blooms/bitset"
 	"github.com/powellquiring/wordle/bitset"
-	"github.com/powellquiring/wordle/gowordle"
 )
 
 // MinHeap is a generic min-heap that can store any type T.
@@ -81,28 +81,11 @@ var fullAnswerCacheHitCount int
 var fullAnswerCacheMissCount int
 var cacheSolutionGuess bool = true
 
-func (d *Dictionary) GoWordleSliceToWordList(goMatching []gowordle.WordleWord) *WordList {
-	possibleWords := d.WordlistEmpty()
-	for _, goWord := range goMatching {
-		wordString := string(goWord[:])
-		word, ok := d.Word(wordString)
-		if !ok {
-			panic("word not in dictionary: " + wordString)
-		}
-		possibleWords.Insert(word)
-	}
-	return possibleWords
-}
-
+// GetFullAnswerForDictionary returns the answer to the guess and all of the dictionary words that give the same answer
 func (d *Dictionary) GetFullAnswerForDictionary(wordlist *WordList, solution WordleWord, guess WordleWord) FullAnswer {
-	goAnswer := gowordle.WordleAnswer2(gowordle.WordleWord([]rune(d.String(solution))), gowordle.WordleWord([]rune(d.String(guess))))
-	goColor := string(goAnswer.Colors[:])
-	goMatching := d.matcher.Matching2(goAnswer)
-	color, ok := StringToAnswer(goColor)
-	if !ok {
-		panic("Color not valid: " + goColor)
-	}
-	possibleWords := d.GoWordleSliceToWordList(goMatching)
+	color := d.Answer(solution, guess)
+	possibleWords := &WordList{}
+	d.matcher.Matching(d.matcher.words[guess], color, possibleWords)
 	return FullAnswer{AnswerColor: color, AnswerMatching: possibleWords}
 }
 
@@ -147,6 +130,10 @@ func (d *Dictionary) GetFullAnswerLength(wordlist *WordList, solution WordleWord
 	return int(bs.IntersectionBitCount((*bitset.BitSet)(wordlist)))
 }
 
+// INIFINITY_SCORE is the NextGuessSearch score of a guess that does not narrow down the possible words, it is never
+// cached
+const INIFINITY_SCORE = 1000000
+
 var subscoreCacheHit int
 var subscoreCacheMiss int
 var subscoreCache = make(map[WordList](int))
@@ -256,6 +243,7 @@ func (d *Dictionary) SortedGuesses(possibleWords *WordList, depth int) *WordScor
 	usedGuesses := make([]bool, d.Len())
 
 	// first try all the possible words most of the time a perfect guess is found in the possible words
+	perfectFound, perfectGuess := false, WordleWord(0)
 	for _, guess := range possibleWords.Range {
 		score := 0
 		for _, solution := range possibleWords.Range {
@@ -263,13 +251,22 @@ func (d *Dictionary) SortedGuesses(possibleWords *WordList, depth int) *WordScor
 			score += matchingLen
 		}
 		if score == lenPossibleWords {
-			// this is the best possible guess there is no need to try any more.
+			// this is the best possible guess there is no need to try any more unless the words are weighted, then
+			// the heaviest perfect guess is best like heaviestWord.
 			// score is 100 for the correct guess and 200 for the rest.
-			return &WordScoreSorter{{Value: guess, Score: uint16((100 + 200*(lenPossibleWords-1)) / lenPossibleWords)}}
+			if !d.Weighted() {
+				return &WordScoreSorter{{Value: guess, Score: uint16(d.perfectGuessScore(possibleWords, guess))}}
+			}
+			if !perfectFound || d.Weight(guess) > d.Weight(perfectGuess) {
+				perfectFound, perfectGuess = true, guess
+			}
 		}
 		wordScoreSorter.Push(WordScore{Value: guess, Score: uint16(score - 2)})
 		usedGuesses[guess] = true
 	}
+	if perfectFound {
+		return &WordScoreSorter{{Value: perfectGuess, Score: uint16(d.perfectGuessScore(possibleWords, perfectGuess))}}
+	}
 	for guess := range lruCache.RangeMRU() {
 		if usedGuesses[guess] {
 			continue // the possible words have already been evaluated
@@ -300,8 +297,6 @@ var depthExceededCount int
 // starting with a subset of the dictionary words (wordlist) give a score to each word in the dictionary
 // based on "guess score" for that word.
 func (d *Dictionary) NextGuessSearch(possibleWords *WordList, depth int) (int, WordleWord) {
-	const INIFINITY_SCORE = 1000000
-
 	// possible words are allocated here to minimize the number of initializations
 	var fullanswerPossibleWords WordList
 
@@ -317,9 +312,12 @@ func (d *Dictionary) NextGuessSearch(possibleWords *WordList, depth int) (int, W
 		return 100, possibleWords.FirstWord() // just guess it
 	}
 	if possibleWordsLen == 2 {
-		// if there are two words choose either of the words and the guesses will be 1 if the right guess and 2 if the wrong guess
-		return 150, possibleWords.FirstWord()
+		// if there are two words choose the more likely word and the guesses will be 1 if the right guess and 2 if the wrong guess
+		guess := d.heaviestWord(possibleWords)
+		return d.perfectGuessScore(possibleWords, guess), guess
 	}
+	// scores are averages over the possible solutions weighted by how likely each solution is, see Weight
+	possibleWeight := d.WordlistWeight(possibleWords)
 
 	// Using the possible words the best guess is the matching one for one solution and 2 for the rest of the solutions.
 	// if retScore, retWordsWithScore, ok := scoreForPossibleWords(game.id); ok {
@@ -357,23 +355,35 @@ func (d *Dictionary) NextGuessSearch(possibleWords *WordList, depth int) (int, W
 
 	bestScore := INIFINITY_SCORE
 	// assume best possible score is a correct guess (100) and getting all the rest of the solutions in 2 guesses
+	perfectScore := func(guessWeight int) int {
+		return (100*guessWeight + 200*(possibleWeight-guessWeight)) / possibleWeight
+	}
+	// the heaviest of the possible word guesses from each position on, a heavier word has a better perfect score
+	heaviestRemaining := make([]int, len(guessesInPossibleWords)+1)
+	for i := len(guessesInPossibleWords) - 1; i >= 0; i-- {
+		heaviestRemaining[i] = max(heaviestRemaining[i+1], d.Weight(guessesInPossibleWords[i]))
+	}
 	var bestGuess WordleWord
 	for guessCount, guess := range append(guessesInPossibleWords, guessesNotInPossibleWords...) {
 		//guessesLen := len(guessesInPossibleWords)
 		score := 0 // running average
+		countedWeight := 0
+		guessWeight := d.Weight(guess)
 		guessInPossibleWordsRemaining := false
-		bestPossibleScore := (100 + 200*(possibleWordsLen-1)) / possibleWordsLen
 		if guessCount < len(guessesInPossibleWords) {
 			// this is a guess that is in the possible words
 			guessInPossibleWordsRemaining = true
-		} else {
-			bestPossibleScore = 200
-		}
-		if bestScore <= bestPossibleScore {
-			// not going to add any more identical scores to the best guess list
+			if bestScore <= perfectScore(heaviestRemaining[guessCount]) {
+				// not going to add any more identical scores to the best guess list
+				break
+			}
+			if bestScore <= perfectScore(guessWeight) {
+				continue // a heavier possible word later in the list can still do better
+			}
+		} else if bestScore <= 200 {
 			break
 		}
-		for count, solution := range possibleWords.Range {
+		for _, solution := range possibleWords.Range {
 			fullAnswer := d.GetFullAnswer(possibleWords, solution, guess, &fullanswerPossibleWords)
 			matching := fullAnswer.AnswerMatching
 			matchingLen := matching.Len()
@@ -404,17 +414,19 @@ func (d *Dictionary) NextGuessSearch(possibleWords *WordList, depth int) (int, W
 				}
 				guessSolutionScore += subscore
 			}
-			score = score + ((guessSolutionScore - score) / (count + 1)) // running average
+			solutionWeight := d.Weight(solution)
+			countedWeight += solutionWeight
+			score = score + ((guessSolutionScore-score)*solutionWeight)/countedWeight // running average
 
 			// 200 is the best for the remaining words, if the current average plus best possible result for the remaining words
 			// is alread over that may as well quit
-			bestPossibleScoreForThisGuess := ((score * (count + 1)) + (200 * (possibleWordsLen - (count + 1)))) / possibleWordsLen
+			bestPossibleScoreForThisGuess := ((score * countedWeight) + (200 * (possibleWeight - countedWeight))) / possibleWeight
 			if guessInPossibleWordsRemaining {
 				// if a correct guess is coming up then the score is 100 for the matching guess and 200 for the rest
-				if possibleWordsLen < (count + 2) {
+				if possibleWeight < (countedWeight + guessWeight) {
 					panic("bad count")
 				}
-				bestPossibleScoreForThisGuess = ((score * (count + 1)) + 100 + (200 * (possibleWordsLen - (count + 2)))) / possibleWordsLen
+				bestPossibleScoreForThisGuess = ((score * countedWeight) + 100*guessWeight + (200 * (possibleWeight - (countedWeight + guessWeight)))) / possibleWeight
 			}
 			if bestPossibleScoreForThisGuess > bestScore {
 				score = bestPossibleScoreForThisGuess // greater then bestScore is all that matters
@@ -445,36 +457,52 @@ func (d *Dictionary) NextGuessSearch(possibleWords *WordList, depth int) (int, W
 	return bestScore, bestGuess
 }
 
-// simulate one game given the first word and the solution
+// DefaultMaxGuesses is the number of guesses allowed in a game of wordle
+const DefaultMaxGuesses = 6
+
+// simulate one game given the first word and the solution, the solver gets 9 guesses, more than DefaultMaxGuesses, so
+// that every game is solved
 func SimulateOneGameGivenFirstWord(dictionary *Dictionary, solution WordleWord, initialGuesses []WordleWord) []WordleWord {
+	guesses, ok := SimulateOneGame(dictionary, solution, initialGuesses, 9)
+	if !ok {
+		panic("unexpected Simulate end")
+	}
+	return guesses
+}
+
+// SimulateOneGame plays one game given the initial guesses and the solution.  The second return value is false if the
+// solution was not guessed within maxGuesses, the guesses made are returned either way.
+func SimulateOneGame(dictionary *Dictionary, solution WordleWord, initialGuesses []WordleWord, maxGuesses int) ([]WordleWord, bool) {
+	return SimulateOneGameFrom(dictionary, dictionary.WordlistAll(), solution, initialGuesses, maxGuesses)
+}
+
+// SimulateOneGameFrom is SimulateOneGame where only some of the words are possible solutions, like the words that
+// have not been the solution of a past puzzle.  The solution must be one of the possible words.
+func SimulateOneGameFrom(dictionary *Dictionary, possibleWords *WordList, solution WordleWord, initialGuesses []WordleWord, maxGuesses int) ([]WordleWord, bool) {
 	// possible words are allocated here to minimize the number of initializations
 	var fullanswerPossibleWords WordList
 	guesses := []WordleWord{}
-	matchingWords := dictionary.WordlistAll()
-	for guessCount := range 8 {
+	matchingWords := possibleWords
+	for guessCount := range maxGuesses {
 		var nextGuess WordleWord
-		if guessCount < len(initialGuesses) {
+		if matchingWords.Len() == 1 {
+			nextGuess = matchingWords.FirstWord()
+		} else if guessCount < len(initialGuesses) {
 			nextGuess = initialGuesses[guessCount]
 		} else {
 			nextGuess = dictionary.NextGuess(matchingWords)
 		}
 		guesses = append(guesses, nextGuess)
+		if nextGuess == solution {
+			return guesses, true
+		}
 		fullAnswer := dictionary.GetFullAnswer(matchingWords, solution, nextGuess, &fullanswerPossibleWords)
 		matchingWords = fullAnswer.AnswerMatching
-		if matchingWords.Len() == 1 {
-			words := matchingWords.Words()
-			if words[0] == solution {
-				if words[0] != nextGuess {
-					// if the solution is the nextGuess it was already added, do not need to add it again
-					guesses = append(guesses, words[0])
-				}
-				return guesses
-			} else {
-				panic("unexpected Simulate end")
-			}
+		if matchingWords.Len() == 0 {
+			panic("unexpected Simulate end")
 		}
 	}
-	panic("unexpected Simulate end")
+	return guesses, false
 }
 
 type GuessAnswer struct {
@@ -482,23 +510,52 @@ type GuessAnswer struct {
 	Answer string
 }
 
-// play wordle against the computer providing the current board state
-// return the next best answer
-func (d *Dictionary) PlayWorldReturnPossible(guessAnswers []GuessAnswer) (WordleWord, *WordList) {
-	goMatching := []gowordle.WordleWord{}
+// PossibleWords returns the words that are consistent with all of the guess/answer pairs.  Answers can have ? for
+// tiles with an unknown colour, see StringToPartialAnswer, the words consistent with any colour for those tiles are
+// kept.  The returned list is empty if the pairs contradict each other, see FindContradiction.
+func (d *Dictionary) PossibleWords(guessAnswers []GuessAnswer) *WordList {
+	possibleWords := d.WordlistAll()
+	var matching WordList
+	for _, guessAnswer := range guessAnswers {
+		if strings.Contains(guessAnswer.Answer, "?") {
+			continue // checked word by word below, see PartialMatching
+		}
+		answer, ok := StringToAnswer(guessAnswer.Answer)
+		if !ok {
+			panic("Answer not valid: " + guessAnswer.Answer)
+		}
+		d.matcher.Matching(d.matcher.letters(guessAnswer.Guess), answer, &matching)
+		(*bitset.BitSet)(possibleWords).IntersectionInPlace((*bitset.BitSet)(&matching), (*bitset.BitSet)(possibleWords))
+	}
+	for _, guessAnswer := range guessAnswers {
+		if strings.Contains(guessAnswer.Answer, "?") {
+			possibleWords = d.PartialMatching(possibleWords, guessAnswer)
+		}
+	}
+	return possibleWords
+}
 
-	var game *gowordle.WordleMatcher
-	for guessCount, guessAnswer := range guessAnswers {
-		if guessCount == 0 {
-			game = d.matcher
-		} else {
-			game = gowordle.NewWordleMatcher(goMatching)
+// PartialMatching returns the possible words whose answer to the guess matches the partial answer, checking each
+// word in turn
+func (d *Dictionary) PartialMatching(possibleWords *WordList, guessAnswer GuessAnswer) *WordList {
+	partialAnswer, ok := StringToPartialAnswer(guessAnswer.Answer)
+	if !ok {
+		panic("Answer not valid: " + guessAnswer.Answer)
+	}
+	guess := []rune(guessAnswer.Guess)
+	ret := d.WordlistEmpty()
+	for _, word := range possibleWords.Range {
+		if partialAnswer.Matches(answerColors([]rune(d.String(word)), guess)) {
+			ret.Insert(word)
 		}
-		goGuess := gowordle.WordleWord([]rune(guessAnswer.Guess))
-		goAnswer := gowordle.WordleWord([]rune(guessAnswer.Answer))
-		goMatching = game.Matching(goGuess, goAnswer)
 	}
-	possibleAnswers := d.GoWordleSliceToWordList(goMatching)
+	return ret
+}
+
+// play wordle against the computer providing the current board state
+// return the next best answer
+func (d *Dictionary) PlayWorldReturnPossible(guessAnswers []GuessAnswer) (WordleWord, *WordList) {
+	possibleAnswers := d.PossibleWords(guessAnswers)
 	ret := d.NextGuess(possibleAnswers)
 	return ret, possibleAnswers
 }
@@ -0,0 +1,143 @@
+package wordle
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Host answers the guesses of a game
+type Host interface {
+	Answer(guess WordleWord) Answer
+}
+
+// SolutionHost is the usual host, the answers are for a fixed solution
+type SolutionHost struct {
+	d        *Dictionary
+	solution WordleWord
+}
+
+func NewSolutionHost(d *Dictionary, solution WordleWord) *SolutionHost {
+	return &SolutionHost{d: d, solution: solution}
+}
+
+func (h *SolutionHost) Answer(guess WordleWord) Answer {
+	return h.d.Answer(h.solution, guess)
+}
+
+// TieBreak chooses between answers that keep the same number of possible words
+type TieBreak int
+
+const (
+	TieBreakFirst        TieBreak = iota // the first answer, ordered by Answer
+	TieBreakFewestGreens                 // the answer with the fewest greens, then the fewest yellows
+	TieBreakRandom                       // a random answer from the seeded source
+)
+
+var tieBreakNames = []string{"first", "fewest-greens", "random"}
+
+func (t TieBreak) String() string {
+	return tieBreakNames[t]
+}
+
+// ParseTieBreak returns the TieBreak for first, fewest-greens or random
+func ParseTieBreak(name string) (TieBreak, bool) {
+	for i, tieBreakName := range tieBreakNames {
+		if name == tieBreakName {
+			return TieBreak(i), true
+		}
+	}
+	return TieBreakFirst, false
+}
+
+// TieBreakNames is the list of names accepted by ParseTieBreak
+func TieBreakNames() string {
+	return strings.Join(tieBreakNames, ", ")
+}
+
+// AdversarialHost never commits to a solution, like Absurdle.  Each answer keeps the largest group of the possible
+// words, the game only ends when one word is left and it is guessed.
+type AdversarialHost struct {
+	d             *Dictionary
+	possibleWords *WordList
+	tieBreak      TieBreak
+	random        *rand.Rand
+}
+
+// NewAdversarialHost starts with all of the words in the dictionary, the seed is only used by TieBreakRandom
+func NewAdversarialHost(d *Dictionary, tieBreak TieBreak, seed int64) *AdversarialHost {
+	return &AdversarialHost{d: d, possibleWords: d.WordlistAll(), tieBreak: tieBreak, random: rand.New(rand.NewSource(seed))}
+}
+
+// PossibleWords are the words that are consistent with all of the answers so far
+func (h *AdversarialHost) PossibleWords() *WordList {
+	return h.possibleWords
+}
+
+func (h *AdversarialHost) Answer(guess WordleWord) Answer {
+	largest := []FullAnswer{}
+	for _, bucket := range h.d.Partition(h.possibleWords, guess) {
+		if len(largest) == 0 || bucket.AnswerMatching.Len() > largest[0].AnswerMatching.Len() {
+			largest = []FullAnswer{bucket}
+		} else if bucket.AnswerMatching.Len() == largest[0].AnswerMatching.Len() {
+			largest = append(largest, bucket)
+		}
+	}
+	chosen := largest[0]
+	switch h.tieBreak {
+	case TieBreakFewestGreens:
+		for _, bucket := range largest[1:] {
+			if greensYellows(bucket.AnswerColor) < greensYellows(chosen.AnswerColor) {
+				chosen = bucket
+			}
+		}
+	case TieBreakRandom:
+		chosen = largest[h.random.Intn(len(largest))]
+	}
+	h.possibleWords = chosen.AnswerMatching
+	return chosen.AnswerColor
+}
+
+// greensYellows orders answers by the number of greens then the number of yellows
+func greensYellows(answer Answer) int {
+	greens, yellows := 0, 0
+	for range 5 {
+		switch Color(answer) & 3 {
+		case Green:
+			greens++
+		case Yellow:
+			yellows++
+		}
+		answer >>= 2
+	}
+	return greens*5 + yellows
+}
+
+// SimulateHostGame plays a game against the host.  The solver uses the initial guesses and then chooses the rest.
+// Returns the guesses and answers and false if the game is not solved in maxGuesses.
+func SimulateHostGame(d *Dictionary, host Host, initialGuesses []WordleWord, maxGuesses int) ([]WordleWord, []Answer, bool) {
+	guesses := []WordleWord{}
+	answers := []Answer{}
+	matchingWords := d.WordlistAll()
+	for guessCount := range maxGuesses {
+		var nextGuess WordleWord
+		if matchingWords.Len() == 1 {
+			nextGuess = matchingWords.FirstWord()
+		} else if guessCount < len(initialGuesses) {
+			nextGuess = initialGuesses[guessCount]
+		} else {
+			nextGuess = d.NextGuess(matchingWords)
+		}
+		answer := host.Answer(nextGuess)
+		guesses = append(guesses, nextGuess)
+		answers = append(answers, answer)
+		if answer == AllGreenAnswer {
+			return guesses, answers, true
+		}
+		for _, bucket := range d.Partition(matchingWords, nextGuess) {
+			if bucket.AnswerColor == answer {
+				matchingWords = bucket.AnswerMatching
+			}
+		}
+	}
+	return guesses, answers, false
+}
@@ -0,0 +1,144 @@
+package wordle
+
+import (
+	"sort"
+
+	"github.com/powellquiring/wordle/bitset"
+)
+
+// noLetter is the letter index of a letter that is in none of the dictionary words
+const noLetter = -1
+
+// Matcher finds the dictionary words that give an answer to a guess with bitsets of the words that have each letter
+// at each position and at least n of each letter.  Letters are indices in the order they first appear in the words.
+type Matcher struct {
+	all         WordList
+	letterIndex map[rune]int
+	words       [][5]int      // letter indices of each word
+	position    [5][]WordList // position[p][letter] words with the letter at position p
+	count       [][5]WordList // count[letter][n-1] words with at least n of the letter
+}
+
+func newMatcher(words []string) *Matcher {
+	ret := &Matcher{letterIndex: map[rune]int{}, words: make([][5]int, len(words))}
+	(*bitset.BitSet)(&ret.all).SetAll(uint(len(words)))
+	for w, word := range words {
+		for p, letter := range []rune(word) {
+			index, ok := ret.letterIndex[letter]
+			if !ok {
+				index = len(ret.count)
+				ret.letterIndex[letter] = index
+				ret.count = append(ret.count, [5]WordList{})
+				for position := range ret.position {
+					ret.position[position] = append(ret.position[position], WordList{})
+				}
+			}
+			ret.words[w][p] = index
+			ret.position[p][index].Insert(WordleWord(w))
+			n := 0
+			for _, other := range ret.words[w][:p] {
+				if other == index {
+					n++
+				}
+			}
+			ret.count[index][n].Insert(WordleWord(w))
+		}
+	}
+	return ret
+}
+
+// letters returns the letter indices of a word that may not be in the dictionary
+func (m *Matcher) letters(word string) [5]int {
+	var ret [5]int
+	for p, letter := range []rune(word) {
+		index, ok := m.letterIndex[letter]
+		if !ok {
+			index = noLetter
+		}
+		ret[p] = index
+	}
+	return ret
+}
+
+// letterRunes returns the letters of the dictionary words in alphabetical order
+func (m *Matcher) letterRunes() []rune {
+	ret := make([]rune, 0, len(m.letterIndex))
+	for letter := range m.letterIndex {
+		ret = append(ret, letter)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// letterCount is the number of the words with at least n of the letter
+func (m *Matcher) letterCount(words *WordList, letter rune, n int) int {
+	index, ok := m.letterIndex[letter]
+	if !ok || n < 1 || n > 5 {
+		return 0
+	}
+	return (*bitset.BitSet)(words).IntersectionBitCount((*bitset.BitSet)(&m.count[index][n-1]))
+}
+
+// positionCount is the number of the words with the letter at the position
+func (m *Matcher) positionCount(words *WordList, position int, letter rune) int {
+	index, ok := m.letterIndex[letter]
+	if !ok {
+		return 0
+	}
+	return (*bitset.BitSet)(words).IntersectionBitCount((*bitset.BitSet)(&m.position[position][index]))
+}
+
+// Matching stores the words that give the answer to the guess in ret.  A green or yellow letter must be in the
+// word that many times and a red letter means there are no more of the letter, see MakeLetterMatch in gowordle.
+func (m *Matcher) Matching(guess [5]int, answer Answer, ret *WordList) {
+	*ret = m.all
+	result := (*bitset.BitSet)(ret)
+	var colors [5]Color
+	for tile := range 5 {
+		colors[tile] = Color(answer>>(2*(4-tile))) & 3
+	}
+	for tile, letter := range guess {
+		if letter == noLetter {
+			if colors[tile] != Red {
+				*ret = WordList{} // no word has the letter
+				return
+			}
+			continue
+		}
+		position := (*bitset.BitSet)(&m.position[tile][letter])
+		if colors[tile] == Green {
+			result.IntersectionInPlace(position, result)
+		} else {
+			result.DifferenceInPlace(position, result)
+		}
+	}
+	for tile, letter := range guess {
+		if letter == noLetter {
+			continue
+		}
+		yellowGreen, red, first := 0, false, true
+		for other, otherLetter := range guess {
+			if otherLetter != letter {
+				continue
+			}
+			if other < tile {
+				first = false // the letter was counted at the earlier tile
+				break
+			}
+			if colors[other] == Red {
+				red = true
+			} else {
+				yellowGreen++
+			}
+		}
+		if !first {
+			continue
+		}
+		if yellowGreen > 0 {
+			result.IntersectionInPlace((*bitset.BitSet)(&m.count[letter][yellowGreen-1]), result)
+		}
+		if red && yellowGreen < 5 {
+			result.DifferenceInPlace((*bitset.BitSet)(&m.count[letter][yellowGreen]), result)
+		}
+	}
+}
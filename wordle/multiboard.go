@@ -0,0 +1,196 @@
+package wordle
+
+import "sort"
+
+// multiBoardShortlist is the number of guesses for each unsolved board that are scored across all of the boards
+const multiBoardShortlist = 8
+
+// MultiBoard tracks the possible words of each board of Dordle, Quordle or Octordle, where every guess is played on
+// all of the boards that are not yet solved
+type MultiBoard struct {
+	d      *Dictionary
+	boards []*WordList // possible words of each board, nil once the board is solved
+}
+
+func (d *Dictionary) NewMultiBoard(boards int) *MultiBoard {
+	ret := &MultiBoard{d: d, boards: make([]*WordList, boards)}
+	for i := range ret.boards {
+		ret.boards[i] = d.WordlistAll()
+	}
+	return ret
+}
+
+// DefaultMultiMaxGuesses is the guess limit of the popular games: 7 for Dordle, 9 for Quordle and 13 for Octordle
+func DefaultMultiMaxGuesses(boards int) int {
+	return boards + 5
+}
+
+func (m *MultiBoard) Boards() int {
+	return len(m.boards)
+}
+
+func (m *MultiBoard) Solved(board int) bool {
+	return m.boards[board] == nil
+}
+
+// Done is true when all of the boards are solved
+func (m *MultiBoard) Done() bool {
+	for board := range m.boards {
+		if !m.Solved(board) {
+			return false
+		}
+	}
+	return true
+}
+
+// PossibleWords of an unsolved board
+func (m *MultiBoard) PossibleWords(board int) *WordList {
+	return m.boards[board]
+}
+
+// Apply keeps the possible words of each board that match its answer to the guess.  The answers of solved boards
+// are ignored.  A board is solved when its answer is all green and it has no possible words when the answers
+// contradict each other.
+func (m *MultiBoard) Apply(guess WordleWord, answers []Answer) {
+	for board, possibleWords := range m.boards {
+		if possibleWords == nil {
+			continue
+		}
+		if answers[board] == AllGreenAnswer {
+			m.boards[board] = nil
+		} else {
+			m.boards[board] = m.d.Matching(possibleWords, guess, answers[board])
+		}
+	}
+}
+
+// Matching returns the possible words that give the answer to the guess
+func (d *Dictionary) Matching(possibleWords *WordList, guess WordleWord, answer Answer) *WordList {
+	var fullanswerPossibleWords WordList
+	for _, solution := range possibleWords.Range {
+		fullAnswer := d.GetFullAnswer(possibleWords, solution, guess, &fullanswerPossibleWords)
+		if fullAnswer.AnswerColor == answer {
+			matching := *fullAnswer.AnswerMatching
+			return &matching
+		}
+	}
+	return d.WordlistEmpty()
+}
+
+// Expected is the sum over the unsolved boards of the expected number of guesses to solve the board, including
+// this guess, when the solver plays the rest of each board on its own
+func (m *MultiBoard) Expected(guess WordleWord) float64 {
+	ret := 0.0
+	for _, possibleWords := range m.boards {
+		if possibleWords != nil {
+			ret += m.d.ExpectedGuesses(possibleWords, guess)
+		}
+	}
+	return ret
+}
+
+// NextGuess returns the guess with the lowest total expected guesses across the boards.  Only a shortlist is scored:
+// the best guess for each board on its own, the possible words of the nearly solved boards and the best guesses of
+// a single ranking of the dictionary across all of the boards, see rankedGuesses.
+func (m *MultiBoard) NextGuess() WordleWord {
+	shortlist := []WordleWord{}
+	seen := make(map[WordleWord]bool)
+	add := func(guess WordleWord) {
+		if !seen[guess] {
+			seen[guess] = true
+			shortlist = append(shortlist, guess)
+		}
+	}
+	unsolved := 0
+	for _, possibleWords := range m.boards {
+		if possibleWords == nil {
+			continue
+		}
+		unsolved++
+		if possibleWords.Len() <= multiBoardShortlist {
+			for _, word := range possibleWords.Range {
+				add(word)
+			}
+		}
+		add(m.d.NextGuess(possibleWords))
+	}
+	for i, guess := range m.rankedGuesses() {
+		if i >= multiBoardShortlist*unsolved {
+			break
+		}
+		add(guess)
+	}
+
+	scores := make(map[WordleWord]float64, len(shortlist))
+	for _, guess := range shortlist {
+		scores[guess] = m.Expected(guess)
+	}
+	// prefer a guess that can solve a board when the expected totals are equal
+	sort.SliceStable(shortlist, func(i, j int) bool {
+		if scores[shortlist[i]] != scores[shortlist[j]] {
+			return scores[shortlist[i]] < scores[shortlist[j]]
+		}
+		return m.candidate(shortlist[i]) && !m.candidate(shortlist[j])
+	})
+	return shortlist[0]
+}
+
+// rankedGuesses orders every word in the dictionary by the RankedGuesses score summed over the unsolved boards, the
+// total number of possible words that remain after the guess, in one pass over the dictionary
+func (m *MultiBoard) rankedGuesses() []WordleWord {
+	scores := make([]int, m.d.Len())
+	ret := make([]WordleWord, m.d.Len())
+	for guess := range m.d.Len() {
+		ret[guess] = WordleWord(guess)
+		for _, possibleWords := range m.boards {
+			if possibleWords == nil {
+				continue
+			}
+			for _, solution := range possibleWords.Range {
+				scores[guess] += m.d.GetFullAnswerLength(possibleWords, solution, WordleWord(guess))
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if scores[ret[i]] != scores[ret[j]] {
+			return scores[ret[i]] < scores[ret[j]]
+		}
+		return m.candidate(ret[i]) && !m.candidate(ret[j])
+	})
+	return ret
+}
+
+// candidate is true if the guess is a possible word of an unsolved board
+func (m *MultiBoard) candidate(guess WordleWord) bool {
+	for _, possibleWords := range m.boards {
+		if possibleWords != nil && possibleWords.Contains(guess) {
+			return true
+		}
+	}
+	return false
+}
+
+// SimulateMultiGame plays one board for each solution.  The solver uses the initial guesses and then chooses the
+// rest.  Returns the guesses and false if the boards are not all solved in maxGuesses.
+func SimulateMultiGame(d *Dictionary, solutions []WordleWord, initialGuesses []WordleWord, maxGuesses int) ([]WordleWord, bool) {
+	multiBoard := d.NewMultiBoard(len(solutions))
+	guesses := []WordleWord{}
+	for guessCount := range maxGuesses {
+		var nextGuess WordleWord
+		if guessCount < len(initialGuesses) {
+			nextGuess = initialGuesses[guessCount]
+		} else {
+			nextGuess = multiBoard.NextGuess()
+		}
+		guesses = append(guesses, nextGuess)
+		answers := make([]Answer, len(solutions))
+		for board, solution := range solutions {
+			answers[board] = d.Answer(solution, nextGuess)
+		}
+		multiBoard.Apply(nextGuess, answers)
+		if multiBoard.Done() {
+			return guesses, true
+		}
+	}
+	return guesses, false
+}
@@ -0,0 +1,269 @@
+package wordle
+
+import (
+	"math"
+	"sort"
+)
+
+// noisyShortlist is the number of guesses scored by noisyRankedGuesses, the best of RankedGuesses
+const noisyShortlist = 100
+
+// noisySearchWords is the most possible words that NoisyNextGuess searches, larger lists are ranked one guess ahead
+const noisySearchWords = 30
+
+// noisySearchDepth is the number of guesses noisySearch looks ahead
+const noisySearchDepth = 3
+
+// noisySearchGuesses is the number of guesses from noisyRankedGuesses that noisySearch tries at each level, along
+// with the possible words
+const noisySearchGuesses = 10
+
+// AnswerDistance is the number of tiles that have a different colour in the two answers
+func AnswerDistance(a, b Answer) int {
+	ret := 0
+	for range 5 {
+		if a&3 != b&3 {
+			ret++
+		}
+		a >>= 2
+		b >>= 2
+	}
+	return ret
+}
+
+// NoisyAnswers returns the answers that differ from the answer in exactly lies tiles, each lying tile shows one of
+// the two other colours
+func NoisyAnswers(answer Answer, lies int) []Answer {
+	ret := []Answer{}
+	var walk func(tile int, lies int, answer Answer)
+	walk = func(tile int, lies int, answer Answer) {
+		if lies == 0 {
+			ret = append(ret, answer)
+			return
+		}
+		if tile < 0 || tile+1 < lies {
+			return
+		}
+		walk(tile-1, lies, answer)
+		shift := 2 * tile
+		color := Color(answer>>shift) & 3
+		for _, lie := range []Color{Red, Yellow, Green} {
+			if lie != color {
+				walk(tile-1, lies-1, answer&^(3<<shift)|Answer(lie)<<shift)
+			}
+		}
+	}
+	walk(4, lies, answer)
+	return ret
+}
+
+// NoisyPossibleWords returns the words that are consistent with the guess/answer pairs when exactly lies tiles of
+// every answer have the wrong colour, as in Fibble.  A word is kept when the true answer for the word differs from
+// the given answer in exactly lies tiles, the union of all the ways of correcting that many tiles.
+func (d *Dictionary) NoisyPossibleWords(guessAnswers []GuessAnswer, lies int) *WordList {
+	possibleWords := d.WordlistAll()
+	for _, guessAnswer := range guessAnswers {
+		guess, ok := d.Word(guessAnswer.Guess)
+		if !ok {
+			panic("guess not in dictionary: " + guessAnswer.Guess)
+		}
+		answer, ok := StringToAnswer(guessAnswer.Answer)
+		if !ok {
+			panic("Answer not valid: " + guessAnswer.Answer)
+		}
+		guessRunes := []rune(d.String(guess))
+		next := d.WordlistEmpty()
+		for _, word := range possibleWords.Range {
+			if AnswerDistance(answerColors([]rune(d.String(word)), guessRunes), answer) == lies {
+				next.Insert(word)
+			}
+		}
+		possibleWords = next
+	}
+	return possibleWords
+}
+
+// NoisyExpectedSize is the expected number of possible words left after the guess when lies tiles of the answer
+// are wrong, each of the noisy answers equally likely.  Nothing is left when the guess is the solution.
+func (d *Dictionary) NoisyExpectedSize(possibleWords *WordList, guess WordleWord, lies int) float64 {
+	answerCount := make(map[Answer]int)
+	for _, solution := range possibleWords.Range {
+		answerCount[d.Answer(solution, guess)]++
+	}
+	// words left after each answer that can be shown
+	shownCount := make(map[Answer]int)
+	for answer, count := range answerCount {
+		for _, shown := range NoisyAnswers(answer, lies) {
+			shownCount[shown] += count
+		}
+	}
+	total := 0.0
+	for answer, count := range answerCount {
+		if answer == AllGreenAnswer {
+			continue // solved, nothing is left
+		}
+		shownAnswers := NoisyAnswers(answer, lies)
+		for _, shown := range shownAnswers {
+			total += float64(count) * float64(shownCount[shown]) / float64(len(shownAnswers))
+		}
+	}
+	return total / float64(possibleWords.Len())
+}
+
+// NoisyNextGuess returns the guess with the fewest expected guesses to solve the possible words when lies tiles of
+// every answer are wrong.  When there are no more than noisySearchWords possible words the game is searched like
+// NextGuessSearch, following every answer that can be shown for each solution, see noisySearch.  Larger lists are
+// ranked one guess ahead by NoisyExpectedSize.
+func (d *Dictionary) NoisyNextGuess(possibleWords *WordList, lies int) WordleWord {
+	if possibleWords.Len() <= 1 {
+		return possibleWords.FirstWord()
+	}
+	if possibleWords.Len() <= noisySearchWords {
+		search := noisySearch{d: d, lies: lies, cache: make(map[noisySearchKey]float64)}
+		for i, guess := range d.noisyRankedGuesses(possibleWords, lies) {
+			if i >= noisySearchGuesses {
+				break
+			}
+			search.guesses = append(search.guesses, guess)
+		}
+		if _, guess, ok := search.bestGuess(possibleWords, noisySearchDepth); ok {
+			return guess
+		}
+	}
+	return d.noisyRankedGuesses(possibleWords, lies)[0]
+}
+
+// noisyRankedGuesses orders a shortlist of guesses by NoisyExpectedSize.  Only a shortlist is scored, the best
+// noisyShortlist guesses of RankedGuesses, which ranks by truthful answers, and the possible words when there are no
+// more than that.
+func (d *Dictionary) noisyRankedGuesses(possibleWords *WordList, lies int) []WordleWord {
+	guesses := []WordleWord{}
+	seen := make(map[WordleWord]bool)
+	if possibleWords.Len() <= noisyShortlist {
+		for _, word := range possibleWords.Range {
+			seen[word] = true
+			guesses = append(guesses, word)
+		}
+	}
+	for i, wordScore := range d.RankedGuesses(possibleWords) {
+		if i >= noisyShortlist {
+			break
+		}
+		if !seen[wordScore.Value] {
+			seen[wordScore.Value] = true
+			guesses = append(guesses, wordScore.Value)
+		}
+	}
+	scores := make(map[WordleWord]float64, len(guesses))
+	for _, guess := range guesses {
+		scores[guess] = d.NoisyExpectedSize(possibleWords, guess, lies)
+	}
+	sort.SliceStable(guesses, func(i, j int) bool {
+		if scores[guesses[i]] != scores[guesses[j]] {
+			return scores[guesses[i]] < scores[guesses[j]]
+		}
+		return possibleWords.Contains(guesses[i]) && !possibleWords.Contains(guesses[j])
+	})
+	return guesses
+}
+
+type noisySearchKey struct {
+	possibleWords WordList
+	depth         int
+}
+
+// noisySearch finds the expected number of guesses to solve a list of possible words when lies tiles of every answer
+// are wrong.  Each solution shows each of its noisy answers with equal probability and the next list of possible
+// words is every word that could have shown that answer.  The search is limited to depth guesses and at each level
+// to the possible words and the guesses, the best noisySearchGuesses of noisyRankedGuesses for the whole search.
+type noisySearch struct {
+	d       *Dictionary
+	lies    int
+	guesses []WordleWord
+	cache   map[noisySearchKey]float64
+}
+
+// expected number of guesses to solve the possible words, including the next guess
+func (s *noisySearch) expected(possibleWords *WordList, depth int) float64 {
+	n := possibleWords.Len()
+	if n == 1 {
+		return 1
+	}
+	if depth <= 1 {
+		return 2 - 1/float64(n) // guess one of the words, assume the rest take one more guess
+	}
+	key := noisySearchKey{possibleWords: *possibleWords, depth: depth}
+	if ret, ok := s.cache[key]; ok {
+		return ret
+	}
+	ret, _, ok := s.bestGuess(possibleWords, depth)
+	if !ok {
+		ret = 2 - 1/float64(n)
+	}
+	s.cache[key] = ret
+	return ret
+}
+
+// bestGuess returns the guess with the fewest expected guesses, ok is false if no guess shrinks the possible words
+// for every answer that can be shown
+func (s *noisySearch) bestGuess(possibleWords *WordList, depth int) (float64, WordleWord, bool) {
+	guesses := []WordleWord{}
+	seen := make(map[WordleWord]bool)
+	for _, word := range possibleWords.Range {
+		seen[word] = true
+		guesses = append(guesses, word)
+	}
+	for _, guess := range s.guesses {
+		if !seen[guess] {
+			guesses = append(guesses, guess)
+		}
+	}
+	bestScore, bestGuess, found := math.Inf(1), WordleWord(0), false
+	for _, guess := range guesses {
+		if score, ok := s.guessExpected(possibleWords, guess, depth); ok && score < bestScore {
+			bestScore, bestGuess, found = score, guess, true
+		}
+	}
+	return bestScore, bestGuess, found
+}
+
+// guessExpected is the expected number of guesses to solve the possible words starting with the guess, ok is false
+// if an answer that can be shown leaves all of the possible words
+func (s *noisySearch) guessExpected(possibleWords *WordList, guess WordleWord, depth int) (float64, bool) {
+	answers := make(map[WordleWord]Answer)
+	answerCount := make(map[Answer]int)
+	for _, solution := range possibleWords.Range {
+		if solution != guess {
+			answers[solution] = s.d.Answer(solution, guess)
+			answerCount[answers[solution]]++
+		}
+	}
+	// expected guesses after each answer that can be shown
+	shownExpected := make(map[Answer]float64)
+	total := 0.0
+	for _, solution := range possibleWords.Range {
+		if solution == guess {
+			total += 1
+			continue
+		}
+		shownAnswers := NoisyAnswers(answers[solution], s.lies)
+		for _, shown := range shownAnswers {
+			expected, ok := shownExpected[shown]
+			if !ok {
+				next := s.d.WordlistEmpty()
+				for word, answer := range answers {
+					if AnswerDistance(answer, shown) == s.lies {
+						next.Insert(word)
+					}
+				}
+				if next.Len() == possibleWords.Len() {
+					return 0, false
+				}
+				expected = s.expected(next, depth-1)
+				shownExpected[shown] = expected
+			}
+			total += (1 + expected) / float64(len(shownAnswers))
+		}
+	}
+	return total / float64(possibleWords.Len()), true
+}
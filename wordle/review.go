@@ -0,0 +1,62 @@
+package wordle
+
+// ReviewStep compares one guess of a played game with the solver.  The reports only have the guess, whether it is a
+// candidate, the expected number of guesses and, when asked for, the worst case.
+type ReviewStep struct {
+	Candidates *WordList   // possible words before the guess
+	Best       GuessReport // the guess chosen by NextGuessSearch
+	Played     GuessReport // the guess that was played
+	Answer     Answer      // answer to the played guess
+	SkillLoss  float64     // expected guesses of the played guess minus the expected guesses of the best guess
+	Luck       float64     // expected guesses of the played guess minus the expected guesses after the answer received
+}
+
+// reviewGuess is the NextGuessSearch guess for the possible words.  The guess for all of the words is remembered,
+// every game reviewed starts with it.
+func (d *Dictionary) reviewGuess(possibleWords *WordList) WordleWord {
+	if possibleWords.Len() == d.Len() && d.openingGuess != nil {
+		return *d.openingGuess
+	}
+	_, guess := d.NextGuessSearch(possibleWords, 0)
+	if possibleWords.Len() == d.Len() {
+		d.openingGuess = &guess
+	}
+	return guess
+}
+
+// ReviewGame replays the guesses of a game with the solution and reports each step until the game is solved or the
+// guesses run out.  Positive luck is an answer that left fewer expected guesses than the average answer.  The worst
+// cases are searched only when worstCase is true, they are slow for a large list of candidates.
+func (d *Dictionary) ReviewGame(solution WordleWord, guesses []WordleWord, worstCase bool) []ReviewStep {
+	ret := []ReviewStep{}
+	possibleWords := d.WordlistAll()
+	for _, guess := range guesses {
+		best := d.reviewGuess(possibleWords)
+		step := ReviewStep{
+			Candidates: possibleWords,
+			Best:       GuessReport{Guess: best, Expected: d.ExpectedGuesses(possibleWords, best), Candidate: possibleWords.Contains(best)},
+			Played:     GuessReport{Guess: guess, Candidate: possibleWords.Contains(guess)},
+			Answer:     d.Answer(solution, guess),
+		}
+		buckets := d.Partition(possibleWords, guess)
+		var answerBucket FullAnswer
+		for _, bucket := range buckets {
+			if bucket.AnswerColor == step.Answer {
+				answerBucket = bucket
+			}
+		}
+		step.Played.Expected = d.bucketsExpected(possibleWords, buckets)
+		if worstCase {
+			step.Best.WorstCase = d.guessWorstCase(possibleWords, best)
+			step.Played.WorstCase = d.guessWorstCase(possibleWords, guess)
+		}
+		step.SkillLoss = step.Played.Expected - step.Best.Expected
+		step.Luck = step.Played.Expected - d.bucketExpected(answerBucket)
+		possibleWords = answerBucket.AnswerMatching
+		ret = append(ret, step)
+		if step.Answer == AllGreenAnswer {
+			break
+		}
+	}
+	return ret
+}
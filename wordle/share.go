@@ -0,0 +1,119 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TileSet is the emoji used for the tiles of a share grid
+type TileSet int
+
+const (
+	TilesDark              TileSet = iota // ⬛🟨🟩
+	TilesLight                            // ⬜🟨🟩
+	TilesHighContrast                     // ⬛🟦🟧
+	TilesHighContrastLight                // ⬜🟦🟧
+)
+
+var tileSetNames = []string{"dark", "light", "high-contrast", "high-contrast-light"}
+
+// tileSetEmoji are the red, yellow and green tiles of each TileSet
+var tileSetEmoji = [][3]string{
+	{"⬛", "🟨", "🟩"},
+	{"⬜", "🟨", "🟩"},
+	{"⬛", "🟦", "🟧"},
+	{"⬜", "🟦", "🟧"},
+}
+
+func (t TileSet) String() string {
+	return tileSetNames[t]
+}
+
+// ParseTileSet returns the TileSet for one of the names in TileSetNames
+func ParseTileSet(name string) (TileSet, bool) {
+	for i, tileSetName := range tileSetNames {
+		if name == tileSetName {
+			return TileSet(i), true
+		}
+	}
+	return TilesDark, false
+}
+
+// TileSetNames is the list of names accepted by ParseTileSet
+func TileSetNames() string {
+	return strings.Join(tileSetNames, ", ")
+}
+
+// Emoji returns the answer as a row of a share grid
+func (a Answer) Emoji(tileSet TileSet) string {
+	ret := ""
+	for tile := range 5 {
+		ret = tileSetEmoji[tileSet][Color(a>>(2*tile))&3] + ret
+	}
+	return ret
+}
+
+// ShareText is the text posted after a game: the "Wordle N X/6" header, with an asterisk for hard mode, followed
+// by the grid.  The number is left out when it is 0 and X is the number of guesses or X when the game was not solved.
+func ShareText(number int, answers []Answer, solved bool, maxGuesses int, hardMode bool, tileSet TileSet) string {
+	header := "Wordle"
+	if number > 0 {
+		header += fmt.Sprintf(" %d", number)
+	}
+	score := "X"
+	if solved {
+		score = fmt.Sprint(len(answers))
+	}
+	header += fmt.Sprintf(" %s/%d", score, maxGuesses)
+	if hardMode {
+		header += "*"
+	}
+	rows := []string{header, ""}
+	for _, answer := range answers {
+		rows = append(rows, answer.Emoji(tileSet))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// ParseShareGrid returns the answers of the rows of a share grid.  The header and blank lines are skipped, any
+// other line must be 5 tiles.
+func ParseShareGrid(text string) ([]Answer, error) {
+	ret := []Answer{}
+	for _, line := range strings.Split(text, "\n") {
+		// some platforms add the emoji variation selector to the square tiles
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\uFE0F", ""))
+		if line == "" || strings.HasPrefix(line, "Wordle") {
+			continue
+		}
+		answer, ok := StringToAnswer(line)
+		if !ok || len([]rune(line)) != 5 {
+			return nil, fmt.Errorf("share grid row is not 5 tiles: %s", line)
+		}
+		ret = append(ret, answer)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("share grid has no rows")
+	}
+	return ret, nil
+}
+
+// IsHardMode is true if every guess uses the hints of the answers before it: green letters stay in place and
+// yellow letters are used again
+func IsHardMode(guesses []string, answers []Answer) bool {
+	for i := 1; i < len(guesses); i++ {
+		guess := []rune(guesses[i])
+		for j := range i {
+			previous := []rune(guesses[j])
+			colors := []rune(answers[j].String())
+			for tile, color := range colors {
+				if color == 'g' && guess[tile] != previous[tile] {
+					return false
+				}
+				if color != 'r' && strings.Count(string(guess), string(previous[tile])) < yellowGreenCount(previous, colors, previous[tile]) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
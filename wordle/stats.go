@@ -0,0 +1,68 @@
+package wordle
+
+import "sort"
+
+// LetterFrequency is the number of possible words with a letter
+type LetterFrequency struct {
+	Letter rune
+	Words  int
+}
+
+// LetterStats describes the letters of the possible words
+type LetterStats struct {
+	Words      int
+	Letters    []LetterFrequency    // words with each letter, most frequent first
+	Repeated   []LetterFrequency    // words with two or more of each letter, most frequent first
+	Positions  [5][]LetterFrequency // words with each letter at each position, most frequent first
+	Unresolved []int                // positions where the possible words do not all have the same letter
+	Splitters  []LetterFrequency    // letters in some but not all of the words, the closest to half of the words first
+}
+
+// LetterStats counts the letters of the possible words overall and by position using the position and count bitsets
+// of the dictionary's Matcher
+func (d *Dictionary) LetterStats(possibleWords *WordList) LetterStats {
+	ret := LetterStats{Words: possibleWords.Len()}
+	if ret.Words == 0 {
+		return ret
+	}
+	for _, letter := range d.matcher.letterRunes() {
+		words := d.matcher.letterCount(possibleWords, letter, 1)
+		if words == 0 {
+			continue
+		}
+		ret.Letters = append(ret.Letters, LetterFrequency{Letter: letter, Words: words})
+		if repeated := d.matcher.letterCount(possibleWords, letter, 2); repeated > 0 {
+			ret.Repeated = append(ret.Repeated, LetterFrequency{Letter: letter, Words: repeated})
+		}
+		if words < ret.Words {
+			ret.Splitters = append(ret.Splitters, LetterFrequency{Letter: letter, Words: words})
+		}
+		for position := range 5 {
+			if positionWords := d.matcher.positionCount(possibleWords, position, letter); positionWords > 0 {
+				ret.Positions[position] = append(ret.Positions[position], LetterFrequency{Letter: letter, Words: positionWords})
+			}
+		}
+	}
+	sortByWords(ret.Letters)
+	sortByWords(ret.Repeated)
+	for position := range 5 {
+		sortByWords(ret.Positions[position])
+		if len(ret.Positions[position]) > 1 {
+			ret.Unresolved = append(ret.Unresolved, position)
+		}
+	}
+	half := func(frequency LetterFrequency) int {
+		return max(2*frequency.Words-ret.Words, ret.Words-2*frequency.Words)
+	}
+	sort.SliceStable(ret.Splitters, func(i, j int) bool {
+		return half(ret.Splitters[i]) < half(ret.Splitters[j])
+	})
+	return ret
+}
+
+// sortByWords orders the most frequent letters first, then alphabetically
+func sortByWords(frequencies []LetterFrequency) {
+	sort.SliceStable(frequencies, func(i, j int) bool {
+		return frequencies[i].Words > frequencies[j].Words
+	})
+}
@@ -0,0 +1,72 @@
+package wordle
+
+import "math"
+
+// MaxWeight is the weight of the most frequent word, see ScaleWeights
+const MaxWeight = 1000
+
+// ScaleWeights turns word frequencies into weights from 1 to MaxWeight in proportion to the most frequent word.  Words
+// without a frequency get the smallest weight, they are possible but unlikely solutions.
+func ScaleWeights(words []string, frequencies map[string]float64) []int {
+	largest := 0.0
+	for _, word := range words {
+		largest = max(largest, frequencies[word])
+	}
+	ret := make([]int, len(words))
+	for i, word := range words {
+		ret[i] = 1
+		if largest > 0 {
+			ret[i] = max(1, int(math.Round(MaxWeight*frequencies[word]/largest)))
+		}
+	}
+	return ret
+}
+
+// Weighted is true if the words have different weights as solutions
+func (d *Dictionary) Weighted() bool {
+	return d.weights != nil
+}
+
+// Weight of the word as a solution, 1 when the dictionary is not weighted
+func (d *Dictionary) Weight(word WordleWord) int {
+	if d.weights == nil {
+		return 1
+	}
+	return d.weights[word]
+}
+
+// WordlistWeight is the total weight of the words, the number of words when the dictionary is not weighted
+func (d *Dictionary) WordlistWeight(wordlist *WordList) int {
+	if d.weights == nil {
+		return wordlist.Len()
+	}
+	ret := 0
+	for _, word := range wordlist.Range {
+		ret += d.weights[word]
+	}
+	return ret
+}
+
+// Probability that the word is the solution when it is one of the possible words
+func (d *Dictionary) Probability(possibleWords *WordList, word WordleWord) float64 {
+	return float64(d.Weight(word)) / float64(d.WordlistWeight(possibleWords))
+}
+
+// heaviestWord is the possible word with the largest weight, the first one if there is a tie
+func (d *Dictionary) heaviestWord(possibleWords *WordList) WordleWord {
+	ret := possibleWords.FirstWord()
+	for _, word := range possibleWords.Range {
+		if d.Weight(word) > d.Weight(ret) {
+			ret = word
+		}
+	}
+	return ret
+}
+
+// perfectGuessScore is the NextGuessSearch score of a possible word that leaves one word for every other solution:
+// one guess when it is the solution and two guesses otherwise, averaged by weight
+func (d *Dictionary) perfectGuessScore(possibleWords *WordList, guess WordleWord) int {
+	possibleWeight := d.WordlistWeight(possibleWords)
+	guessWeight := d.Weight(guess)
+	return (100*guessWeight + 200*(possibleWeight-guessWeight)) / possibleWeight
+}
@@ -4,9 +4,7 @@ import (
 	"strconv"
 
 	"github.com/powellquiring/wordle/bitset"
-
 	//	"github.com/bits-and-blooms/bitset"
-	"github.com/powellquiring/wordle/gowordle"
 )
 
 // WordleWord is an index into the dictionary
@@ -22,13 +20,22 @@ const (
 	Red Color = iota
 	Yellow
 	Green
+	Unknown // only in a PartialAnswer, the colour of the tile is not known
 )
 
+// PartialAnswer is an Answer where some of the tiles can be Unknown
+type PartialAnswer uint16
+
 type Dictionary struct {
+	alphabet        *Alphabet
 	words           []string
 	stringToWord    map[string]WordleWord
-	matcher         *gowordle.WordleMatcher
+	matcher         *Matcher
 	fullAnswerCache [][]FullAnswer
+	worstCaseCache  *wordListCache[int]     // WorstCase of the possible words
+	expectedCache   *wordListCache[float64] // SolverExpected of the possible words
+	openingGuess    *WordleWord             // reviewGuess of all of the words, nil until it is searched
+	weights         []int                   // weight of each word as a solution, nil when all of the words are equally likely
 }
 
 type FullAnswer struct {
@@ -36,17 +43,18 @@ type FullAnswer struct {
 	AnswerMatching *WordList
 }
 
+// StringToAnswer parses r, y and g or the emoji tiles of a share grid, see TileSet
 func StringToAnswer(colors string) (Answer, bool) {
 	ret := Answer(0)
 	ok := true
 	for _, color := range colors {
 		ret <<= 2
 		switch color {
-		case 'r':
+		case 'r', '⬛', '⬜':
 			ret |= Answer(Red)
-		case 'y':
+		case 'y', '🟨', '🟦':
 			ret |= Answer(Yellow)
-		case 'g':
+		case 'g', '🟩', '🟧':
 			ret |= Answer(Green)
 		default:
 			ok = false
@@ -55,6 +63,54 @@ func StringToAnswer(colors string) (Answer, bool) {
 	return ret, ok
 }
 
+// StringToPartialAnswer is StringToAnswer that also accepts ? for a tile with an unknown colour
+func StringToPartialAnswer(colors string) (PartialAnswer, bool) {
+	ret := PartialAnswer(0)
+	ok := true
+	for _, color := range colors {
+		ret <<= 2
+		switch color {
+		case '?':
+			ret |= PartialAnswer(Unknown)
+		default:
+			answer, colorOk := StringToAnswer(string(color))
+			ret |= PartialAnswer(answer)
+			ok = ok && colorOk
+		}
+	}
+	return ret, ok
+}
+
+// Answer returns the answer if none of the tiles are Unknown
+func (p PartialAnswer) Answer() (Answer, bool) {
+	for tile := range 5 {
+		if Color(p>>(2*tile))&3 == Unknown {
+			return 0, false
+		}
+	}
+	return Answer(p), true
+}
+
+// Matches is true if the answer has the same colour as every tile that is known
+func (p PartialAnswer) Matches(answer Answer) bool {
+	for tile := range 5 {
+		shift := 2 * tile
+		color := Color(p>>shift) & 3
+		if color != Unknown && color != Color(answer>>shift)&3 {
+			return false
+		}
+	}
+	return true
+}
+
+func (p PartialAnswer) String() string {
+	ret := ""
+	for tile := range 5 {
+		ret = string("ryg?"[Color(p>>(2*tile))&3]) + ret
+	}
+	return ret
+}
+
 func (a Answer) String() string {
 	answer := Color(a)
 	ret := ""
@@ -78,13 +134,30 @@ func (a Answer) String() string {
 	return ret
 }
 
+// NewDictionary of english words
 func NewDictionary(strings []string) *Dictionary {
-	ret := &Dictionary{words: strings}
+	return NewDictionaryWithAlphabet(strings, English)
+}
+
+// NewDictionaryWithAlphabet of sorted words that are normalized for the alphabet, see Alphabet.NormalizeWords
+func NewDictionaryWithAlphabet(strings []string, alphabet *Alphabet) *Dictionary {
+	return NewWeightedDictionary(strings, alphabet, nil)
+}
+
+// NewWeightedDictionary is NewDictionaryWithAlphabet where each word is a solution in proportion to its weight, see
+// ScaleWeights.  Nil weights are all words equally likely.
+func NewWeightedDictionary(strings []string, alphabet *Alphabet, weights []int) *Dictionary {
+	if weights != nil && len(weights) != len(strings) {
+		panic("must have a weight for each word")
+	}
+	ret := &Dictionary{alphabet: alphabet, words: strings, weights: weights}
 	ret.stringToWord = make(map[string]WordleWord)
 	for i, word := range strings {
 		ret.stringToWord[word] = WordleWord(i)
 	}
-	ret.matcher = gowordle.NewWordleMatcher(gowordle.StringsToWordleWords(strings))
+	ret.matcher = newMatcher(strings)
+	ret.worstCaseCache = newWordListCache[int](wordListCacheSize)
+	ret.expectedCache = newWordListCache[float64](wordListCacheSize)
 	stringsLen := len(strings)
 	ret.fullAnswerCache = make([][]FullAnswer, stringsLen)
 	for i := range ret.fullAnswerCache {
@@ -93,6 +166,11 @@ func NewDictionary(strings []string) *Dictionary {
 	return ret
 }
 
+// Alphabet of the words in the dictionary
+func (d *Dictionary) Alphabet() *Alphabet {
+	return d.alphabet
+}
+
 func (d *Dictionary) Len() int {
 	return len(d.words)
 }
@@ -110,6 +188,11 @@ func (d *Dictionary) WordlistAllMinusThese(subtractThese *WordList) *WordList {
 	return ret
 }
 
+// WordlistMinus returns the words in the wordlist that are not in subtractThese
+func (d *Dictionary) WordlistMinus(wordlist *WordList, subtractThese *WordList) *WordList {
+	return (*WordList)((*bitset.BitSet)(wordlist).Difference((*bitset.BitSet)(subtractThese)))
+}
+
 func (d *Dictionary) WordlistFromStrings(strings []string) *WordList {
 	ret := d.WordlistEmpty()
 	for _, word := range strings {
@@ -127,8 +210,12 @@ func (d *Dictionary) WordlistEmpty() *WordList {
 	return (*WordList)(ret)
 }
 
+// Word returns the word for the string, the string is normalized for the alphabet if it is not found as is
 func (d *Dictionary) Word(wordleWordString string) (WordleWord, bool) {
 	ret, ok := d.stringToWord[wordleWordString]
+	if !ok {
+		ret, ok = d.stringToWord[d.alphabet.Normalize(wordleWordString)]
+	}
 	return ret, ok
 }
 
@@ -162,6 +249,41 @@ func (d *Dictionary) WordSliceToStrings(wordSlice []WordleWord) []string {
 	return ret
 }
 
+// Answer returns the colors shown for the guess when the solution is the word to find
+func (d *Dictionary) Answer(solution, guess WordleWord) Answer {
+	return answerColors(d.matcher.words[solution][:], d.matcher.words[guess][:])
+}
+
+// answerColors computes the answer without the matching words, each yellow uses up one of the solution letters that
+// is not green.  The letters are runes or the letter indices of a Matcher.
+func answerColors[Letter rune | int](solution, guess []Letter) Answer {
+	var colors [5]Color
+	var used [5]bool
+	for i := range guess {
+		if guess[i] == solution[i] {
+			colors[i] = Green
+			used[i] = true
+		}
+	}
+	for i := range guess {
+		if colors[i] == Green {
+			continue
+		}
+		for j := range solution {
+			if !used[j] && guess[i] == solution[j] {
+				colors[i] = Yellow
+				used[j] = true
+				break
+			}
+		}
+	}
+	ret := Answer(0)
+	for _, color := range colors {
+		ret = ret<<2 | Answer(color)
+	}
+	return ret
+}
+
 // given a wordlist a solution and a guess return the answer and new wordlist
 func (d *Dictionary) NextGuess(wordlist *WordList) WordleWord {
 	_, guess := d.NextGuessSearch(wordlist, 0)
@@ -206,3 +328,8 @@ func (wordlist *WordList) Insert(word WordleWord) {
 	bs := (*bitset.BitSet)(wordlist)
 	bs.Set(uint(word))
 }
+
+func (wordlist *WordList) Contains(word WordleWord) bool {
+	bs := (*bitset.BitSet)(wordlist)
+	return bs.Test(uint(word))
+}
@@ -2,7 +2,10 @@ package wordle
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func stringToWordOrPanic(d *Dictionary, s string) WordleWord {
@@ -17,3 +20,375 @@ func BenchmarkSimulate(t *testing.B) {
 	guesses := SimulateOneGameGivenFirstWord(d, stringToWordOrPanic(d, "hello"), []WordleWord{stringToWordOrPanic(d, "raise")})
 	fmt.Println(guesses)
 }
+
+func TestFindContradiction(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	guessAnswers := []GuessAnswer{{Guess: "raise", Answer: "rrrrr"}, {Guess: "cloth", Answer: "gggrg"}}
+	contradiction, ok := d.FindContradiction(guessAnswers)
+	assert.True(t, ok)
+	assert.Equal(t, 1, contradiction.Pair)
+	assert.Equal(t, 2, contradiction.Remaining)
+	assert.Equal(t, 4, contradiction.Tile)
+	assert.Equal(t, "h is in position 5", contradiction.Constraint)
+	assert.Contains(t, contradiction.Corrections, Correction{Pair: 1, Tile: 3, GuessAnswer: GuessAnswer{Guess: "cloth", Answer: "ggggg"}, PossibleWords: 1})
+
+	_, ok = d.FindContradiction(guessAnswers[:1])
+	assert.False(t, ok)
+}
+
+func TestExplainGuess(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	report := d.ExplainGuess(d.WordlistAll(), stringToWordOrPanic(d, "crate"))
+	assert.Equal(t, 4, report.Buckets)
+	assert.Equal(t, 1, report.LargestBucket)
+	assert.Equal(t, 2.0, report.Entropy)
+	assert.Equal(t, 1.75, report.Expected)
+	assert.Equal(t, 2, report.WorstCase)
+	assert.True(t, report.Candidate)
+	assert.Equal(t, report.Expected, d.ExpectedGuesses(d.WordlistAll(), stringToWordOrPanic(d, "crate")))
+	// raise is solved in 1, crate in 2 and the solver needs 1.5 more on average for cloth and clown
+	assert.InDelta(t, (1+2+2.5+2.5)/4.0, d.ExpectedGuesses(d.WordlistAll(), stringToWordOrPanic(d, "raise")), 1e-9)
+}
+
+func TestAdversarialHost(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	host := NewAdversarialHost(d, TieBreakFirst, 1)
+	assert.Equal(t, "rrrrr", host.Answer(stringToWordOrPanic(d, "raise")).String())
+	assert.Equal(t, []string{"cloth", "clown"}, d.WordlistStrings(host.PossibleWords()))
+	assert.Equal(t, "gggrr", host.Answer(stringToWordOrPanic(d, "clown")).String())
+	assert.Equal(t, []string{"cloth"}, d.WordlistStrings(host.PossibleWords()))
+
+	guesses, answers, solved := SimulateHostGame(d, NewAdversarialHost(d, TieBreakFewestGreens, 1), []WordleWord{stringToWordOrPanic(d, "raise")}, DefaultMaxGuesses)
+	assert.True(t, solved)
+	assert.Equal(t, AllGreenAnswer, answers[len(answers)-1])
+	assert.Equal(t, "rrrrr", answers[0].String())
+	assert.Len(t, guesses, 3)
+}
+
+func TestMultiBoard(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	raise := stringToWordOrPanic(d, "raise")
+	multiBoard := d.NewMultiBoard(2)
+	multiBoard.Apply(raise, []Answer{d.Answer(stringToWordOrPanic(d, "cloth"), raise), AllGreenAnswer})
+	assert.True(t, multiBoard.Solved(1))
+	assert.False(t, multiBoard.Done())
+	assert.Equal(t, []string{"cloth", "clown"}, d.WordlistStrings(multiBoard.PossibleWords(0)))
+	assert.Equal(t, 1.5, multiBoard.Expected(stringToWordOrPanic(d, "cloth")))
+
+	solutions := []WordleWord{stringToWordOrPanic(d, "crate"), stringToWordOrPanic(d, "clown")}
+	guesses, solved := SimulateMultiGame(d, solutions, nil, DefaultMultiMaxGuesses(2))
+	assert.True(t, solved)
+	assert.Contains(t, guesses, solutions[0])
+	assert.Contains(t, guesses, solutions[1])
+}
+
+func TestNoisyPossibleWords(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	assert.Len(t, NoisyAnswers(AllGreenAnswer, 1), 10)
+	assert.Len(t, NoisyAnswers(AllGreenAnswer, 2), 40)
+	for _, solution := range d.WordlistAll().Range {
+		for _, guess := range d.WordlistAll().Range {
+			assert.Equal(t, d.Answer(solution, guess), answerColors([]rune(d.String(solution)), []rune(d.String(guess))))
+		}
+	}
+
+	// the true answers are cloth gggrr and clown ggggg, each one tile from ggggr
+	guessAnswers := []GuessAnswer{{Guess: "clown", Answer: "ggggr"}}
+	possibleWords := d.NoisyPossibleWords(guessAnswers, 1)
+	assert.Equal(t, []string{"cloth", "clown"}, d.WordlistStrings(possibleWords))
+	assert.True(t, possibleWords.Contains(d.NoisyNextGuess(possibleWords, 1)))
+	// guessing cloth solves it in 1, any answer shown for clown leaves only clown
+	search := noisySearch{d: d, lies: 1, cache: make(map[noisySearchKey]float64)}
+	expected, ok := search.guessExpected(possibleWords, stringToWordOrPanic(d, "cloth"), noisySearchDepth)
+	assert.True(t, ok)
+	assert.InDelta(t, 1.5, expected, 1e-9)
+	best, bestGuess, ok := search.bestGuess(d.WordlistAll(), noisySearchDepth)
+	assert.True(t, ok)
+	assert.Equal(t, bestGuess, d.NoisyNextGuess(d.WordlistAll(), 1))
+	for _, guess := range d.WordlistAll().Range {
+		if expected, ok := search.guessExpected(d.WordlistAll(), guess, noisySearchDepth); ok {
+			assert.LessOrEqual(t, best, expected)
+		}
+	}
+	// crate is grrrr with no lie so it is not possible, raise is rrrrr
+	assert.Equal(t, []string{"raise"}, d.WordlistStrings(d.NoisyPossibleWords([]GuessAnswer{{Guess: "clown", Answer: "grrrr"}}, 1)))
+}
+
+func TestPartialAnswer(t *testing.T) {
+	partialAnswer, ok := StringToPartialAnswer("g?rry")
+	assert.True(t, ok)
+	assert.Equal(t, "g?rry", partialAnswer.String())
+	_, ok = partialAnswer.Answer()
+	assert.False(t, ok)
+	for _, answer := range []string{"grrry", "gyrry", "ggrry"} {
+		a, _ := StringToAnswer(answer)
+		assert.True(t, partialAnswer.Matches(a), answer)
+	}
+	a, _ := StringToAnswer("grrrr")
+	assert.False(t, partialAnswer.Matches(a))
+
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	// cloth is gggrr and clown is ggggg
+	assert.Equal(t, []string{"cloth", "clown"}, d.WordlistStrings(d.PossibleWords([]GuessAnswer{{Guess: "clown", Answer: "ggg??"}})))
+	assert.Equal(t, []string{"cloth"}, d.WordlistStrings(d.PossibleWords([]GuessAnswer{{Guess: "raise", Answer: "rrrrr"}, {Guess: "clown", Answer: "ggg?r"}})))
+	_, ok = d.FindContradiction([]GuessAnswer{{Guess: "clown", Answer: "?????"}})
+	assert.False(t, ok)
+}
+
+func TestLetterStats(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	letterStats := d.LetterStats(d.PossibleWords([]GuessAnswer{{Guess: "raise", Answer: "rrrrr"}}))
+	assert.Equal(t, 2, letterStats.Words)
+	assert.Equal(t, LetterFrequency{Letter: 'c', Words: 2}, letterStats.Letters[0])
+	assert.Equal(t, []LetterFrequency{{Letter: 'c', Words: 2}}, letterStats.Positions[0])
+	assert.Equal(t, []LetterFrequency{{Letter: 't', Words: 1}, {Letter: 'w', Words: 1}}, letterStats.Positions[3])
+	assert.Equal(t, []int{3, 4}, letterStats.Unresolved)
+	assert.Equal(t, []LetterFrequency{{'h', 1}, {'n', 1}, {'t', 1}, {'w', 1}}, letterStats.Splitters)
+	assert.Empty(t, letterStats.Repeated)
+}
+
+func TestCheckWords(t *testing.T) {
+	lines := []string{"about", "Above", "about", "acorn", "abbey", "café", "ab", ""}
+	problems := []string{}
+	for _, problem := range CheckWords(lines) {
+		problems = append(problems, problem.String())
+	}
+	assert.Equal(t, []string{
+		`line 2 "Above": upper case letters`,
+		`line 3 "about": duplicate of line 1`,
+		`line 5 "abbey": not sorted, comes before "acorn"`,
+		`line 6 "café": letters not in the english alphabet: é`,
+		`line 7 "ab": 2 letters, not 5`,
+		`line 7 "ab": not sorted, comes before "café"`,
+		`line 8 "": blank line`,
+	}, problems)
+	assert.Empty(t, CheckWords(SortedWordleDictionary()))
+
+	words, dropped := NormalizeWords(lines)
+	assert.Equal(t, []string{"abbey", "about", "above", "acorn"}, words)
+	assert.Equal(t, []string{"café", "ab"}, dropped)
+
+	added, removed := DiffWords(words, []string{"about", "acorn", "actor"})
+	assert.Equal(t, []string{"actor"}, added)
+	assert.Equal(t, []string{"abbey", "above"}, removed)
+}
+
+func TestAlphabet(t *testing.T) {
+	spanish, ok := AlphabetByName("spanish")
+	assert.True(t, ok)
+	assert.Equal(t, "arbol", spanish.Normalize(" Árbol "))
+	assert.Equal(t, "cañon", spanish.Normalize("cañón"))
+	assert.True(t, spanish.Valid("cañon"))
+	assert.False(t, English.Valid("cañon"))
+	words, dropped := spanish.NormalizeWords([]string{"señas", "árbol", "niño", "Peñas"})
+	assert.Equal(t, []string{"arbol", "peñas", "señas"}, words)
+	assert.Equal(t, []string{"niño"}, dropped)
+
+	d := NewDictionaryWithAlphabet(words, spanish)
+	peñas, ok := d.Word("PEÑAS")
+	assert.True(t, ok)
+	assert.Equal(t, "rgggg", d.Answer(stringToWordOrPanic(d, "señas"), peñas).String())
+	assert.Equal(t, []string{"peñas", "señas"}, d.WordlistStrings(d.PossibleWords([]GuessAnswer{{Guess: "arbol", Answer: "yrrrr"}})))
+
+	german, _ := AlphabetByName("german")
+	d = NewDictionaryWithAlphabet([]string{"büßen", "maßen", "ßaaaa"}, german)
+	assert.Equal(t, "rrggg", d.Answer(stringToWordOrPanic(d, "büßen"), stringToWordOrPanic(d, "maßen")).String())
+	assert.Equal(t, "rryrr", d.Answer(stringToWordOrPanic(d, "ßaaaa"), stringToWordOrPanic(d, "büßen")).String())
+}
+
+func TestShareText(t *testing.T) {
+	answers := []Answer{}
+	for _, colors := range []string{"grryy", "ggggg"} {
+		answer, _ := StringToAnswer(colors)
+		answers = append(answers, answer)
+	}
+	assert.Equal(t, "🟩⬛⬛🟨🟨", answers[0].Emoji(TilesDark))
+	assert.Equal(t, "🟧⬜⬜🟦🟦", answers[0].Emoji(TilesHighContrastLight))
+	assert.Equal(t, "Wordle 1234 2/6*\n\n🟩⬛⬛🟨🟨\n🟩🟩🟩🟩🟩", ShareText(1234, answers, true, 6, true, TilesDark))
+	assert.Equal(t, "Wordle X/6\n\n🟩⬛⬛🟨🟨\n🟩🟩🟩🟩🟩", ShareText(0, answers, false, 6, false, TilesDark))
+
+	parsed, err := ParseShareGrid("Wordle 1234 2/6*\n\n🟩⬛️⬛️🟨🟨\n🟧🟧🟧🟧🟧\n")
+	assert.NoError(t, err)
+	assert.Equal(t, answers, parsed)
+	_, err = ParseShareGrid("🟩⬛⬛🟨\n")
+	assert.Error(t, err)
+	_, err = ParseShareGrid("Wordle 1234 2/6\n")
+	assert.Error(t, err)
+
+	tileSet, ok := ParseTileSet("high-contrast")
+	assert.True(t, ok)
+	assert.Equal(t, TilesHighContrast, tileSet)
+	_, ok = ParseTileSet("neon")
+	assert.False(t, ok)
+
+	assert.True(t, IsHardMode([]string{"about", "acute"}, answers))
+	assert.False(t, IsHardMode([]string{"about", "crate"}, answers))
+	assert.False(t, IsHardMode([]string{"about", "azure"}, answers))
+}
+
+func TestDeduce(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	green, _ := StringToAnswer("ggggg")
+	grid := []Answer{d.Answer(stringToWordOrPanic(d, "crate"), stringToWordOrPanic(d, "raise")), green}
+	solutions := []string{}
+	for _, deduction := range d.Deduce([][]Answer{grid}) {
+		solutions = append(solutions, d.String(deduction.Solution))
+	}
+	assert.Equal(t, []string{"crate"}, solutions)
+
+	yellow, _ := StringToAnswer("yyyyy")
+	assert.Empty(t, d.Deduce([][]Answer{{yellow}}))
+	assert.Len(t, d.Deduce([][]Answer{{green}}), 4)
+}
+
+func TestReviewGame(t *testing.T) {
+	d := NewDictionary([]string{"cloth", "clown", "crate", "raise"})
+	steps := d.ReviewGame(stringToWordOrPanic(d, "crate"), d.StringsToWordSlice([]string{"cloth", "crate", "raise"}), true)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, 4, steps[0].Candidates.Len())
+	assert.Equal(t, "grrgr", steps[0].Answer.String())
+	assert.Equal(t, 1.75, steps[0].Played.Expected)
+	assert.Equal(t, -0.25, steps[0].Luck)
+	assert.InDelta(t, steps[0].Played.Expected-steps[0].Best.Expected, steps[0].SkillLoss, 1e-9)
+	assert.GreaterOrEqual(t, steps[0].SkillLoss, 0.0)
+	assert.Equal(t, 1, steps[1].Candidates.Len())
+	assert.Equal(t, AllGreenAnswer, steps[1].Answer)
+	assert.Equal(t, 0.0, steps[1].SkillLoss)
+	assert.Equal(t, 0.0, steps[1].Luck)
+	assert.Equal(t, 2, steps[0].Played.WorstCase)
+	assert.Equal(t, 0, d.ReviewGame(stringToWordOrPanic(d, "crate"), d.StringsToWordSlice([]string{"cloth"}), false)[0].Played.WorstCase)
+	// only the opening guess is remembered
+	assert.Equal(t, steps[0].Best.Guess, *d.openingGuess)
+	assert.Equal(t, d.NextGuess(steps[1].Candidates), d.reviewGuess(steps[1].Candidates))
+}
+
+func TestWeights(t *testing.T) {
+	words := []string{"cloth", "clown"}
+	weights := ScaleWeights(words, map[string]float64{"clown": 3, "other": 6})
+	assert.Equal(t, []int{1, 1000}, weights)
+	assert.Equal(t, []int{1, 1}, ScaleWeights(words, nil))
+
+	d := NewWeightedDictionary(words, English, weights)
+	assert.True(t, d.Weighted())
+	all := d.WordlistAll()
+	assert.Equal(t, 1001, d.WordlistWeight(all))
+	score, guess := d.NextGuessSearch(all, 0)
+	assert.Equal(t, "clown", d.String(guess))
+	assert.Equal(t, (100*1000+200)/1001, score)
+	assert.InDelta(t, 1000.0/1001, d.Probability(all, guess), 1e-9)
+	assert.InDelta(t, float64(100+200*1000)/float64(100*1001), d.ExplainGuess(all, stringToWordOrPanic(d, "cloth")).Expected, 1e-9)
+
+	// the lighter grate is searched first, the heavier villa is still tried
+	words = []string{"grate", "villa", "hound", "state", "silly", "agate"}
+	d = NewWeightedDictionary(words, English, []int{19, 20, 15, 1, 15, 5})
+	score, guess = d.NextGuessSearch(d.WordlistAll(), 0)
+	assert.Equal(t, "villa", d.String(guess))
+	assert.Equal(t, 180, score)
+
+	// every word is a perfect guess, the heaviest is best
+	words = []string{"cloth", "clout", "clown"}
+	d = NewWeightedDictionary(words, English, []int{1, 1000, 1})
+	score, guess = d.NextGuessSearch(d.WordlistAll(), 0)
+	assert.Equal(t, "clout", d.String(guess))
+	assert.Equal(t, (100*1000+200*2)/1002, score)
+
+	words = []string{"cloth", "clown"}
+	d = NewDictionary(words)
+	assert.False(t, d.Weighted())
+	score, guess = d.NextGuessSearch(d.WordlistAll(), 0)
+	assert.Equal(t, "cloth", d.String(guess))
+	assert.Equal(t, 150, score)
+}
+
+// matchesAnswer is the rule of the Matcher checked one word at a time: green tiles are the letter of the word and
+// the other tiles are not, a letter is in the word at least as many times as it is green or yellow and no more
+// times when it is also red
+func matchesAnswer(word, guess, answer string) bool {
+	wordRunes, guessRunes := []rune(word), []rune(guess)
+	for tile := range guessRunes {
+		if (answer[tile] == 'g') != (wordRunes[tile] == guessRunes[tile]) {
+			return false
+		}
+	}
+	for _, letter := range guessRunes {
+		yellowGreen, red := 0, false
+		for tile := range guessRunes {
+			if guessRunes[tile] == letter {
+				if answer[tile] == 'r' {
+					red = true
+				} else {
+					yellowGreen++
+				}
+			}
+		}
+		count := strings.Count(word, string(letter))
+		if count < yellowGreen || red && count > yellowGreen {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatcher(t *testing.T) {
+	words := []string{}
+	for i, word := range SortedWordleDictionary() {
+		if i%20 == 0 || word == "eerie" || word == "sassy" {
+			words = append(words, word)
+		}
+	}
+	d := NewDictionary(words)
+	answers := []string{"rrrrr"}
+	for range 5 {
+		next := []string{}
+		for _, answer := range answers {
+			for _, color := range "ryg" {
+				next = append(next, answer[1:]+string(color))
+			}
+		}
+		answers = next
+	}
+	for _, guess := range append(words, "zzzzz", "eeeee") {
+		for _, answer := range answers {
+			matching := []string{}
+			for _, word := range words {
+				if matchesAnswer(word, guess, answer) {
+					matching = append(matching, word)
+				}
+			}
+			assert.Equal(t, matching, d.WordlistStrings(d.PossibleWords([]GuessAnswer{{Guess: guess, Answer: answer}})), guess+" "+answer)
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	c, ok := NewConstraint([]GuessAnswer{{Guess: "arose", Answer: "yrryr"}, {Guess: "sassy", Answer: "yggrr"}})
+	assert.True(t, ok)
+	assert.Equal(t, "a in position 2, s in position 3, a not in position 1, s not in position 1, s not in position 4, "+
+		"at least one a, no e, no o, no r, exactly two s, no y", c.String())
+	_, ok = NewConstraint([]GuessAnswer{{Guess: "arose", Answer: "yrxyr"}})
+	assert.False(t, ok)
+
+	d := NewDictionary(SortedWordleDictionary())
+	histories := [][]GuessAnswer{
+		{{Guess: "arose", Answer: "yrryr"}, {Guess: "sassy", Answer: "yggrr"}},
+		{{Guess: "arose", Answer: "yrryr"}, {Guess: "sassy", Answer: "ryygr"}},
+		{{Guess: "eerie", Answer: "rgrrg"}},
+		{{Guess: "crane", Answer: "rrrrr"}, {Guess: "built", Answer: "ryrrg"}},
+		{{Guess: "speed", Answer: "ry?rr"}},
+		{{Guess: "crane", Answer: "grrrr"}, {Guess: "crane", Answer: "rgrrr"}},
+		{{Guess: "crane", Answer: "🟩⬛⬛⬛⬛"}},
+	}
+	for _, history := range histories {
+		merged := Constraint{}
+		for _, guessAnswer := range history {
+			one, ok := NewConstraint([]GuessAnswer{guessAnswer})
+			assert.True(t, ok)
+			merged.Merge(one)
+		}
+		c, _ := NewConstraint(history)
+		assert.Equal(t, d.WordlistStrings(d.PossibleWords(history)), d.WordlistStrings(c.Matching(d)), c.String())
+		assert.Equal(t, d.WordlistStrings(c.Matching(d)), d.WordlistStrings(merged.Matching(d)), merged.String())
+	}
+	c, _ = NewConstraint([]GuessAnswer{{Guess: "crane", Answer: "🟩⬛⬛⬛⬛"}})
+	assert.Equal(t, 25, c.Matching(d).Len())
+}